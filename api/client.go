@@ -0,0 +1,153 @@
+package api
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/structs"
+	"github.com/anyweez/matchgrab/utils"
+)
+
+// Client : Makes requests to the Riot API. Clients don't hold any state between requests so a
+// single client can be shared by any number of goroutines.
+type Client struct {
+	BaseURL string        // `{platform}` is replaced with the lowercase platform ID
+	APIKey  string        // sent with every request
+	Timeout time.Duration // timeout on each request; zero means no timeout
+}
+
+// NewClient : Create a client for the specified API key. Requests go to Riot's production API
+// unless BaseURL is changed.
+func NewClient(key string) *Client {
+	return &Client{
+		BaseURL: config.DefaultAPIBaseURL,
+		APIKey:  key,
+		Timeout: 20 * time.Second,
+	}
+}
+
+// ConfigClient : Create a client from the application config (see config.Setup()).
+func ConfigClient() *Client {
+	return &Client{
+		BaseURL: config.Config.APIBaseURL,
+		APIKey:  config.Config.RiotAPIKey,
+		Timeout: config.Config.HTTPTimeout,
+	}
+}
+
+// URL : Build a URL for an endpoint on the specified platform's API host. The path is
+// formatted with the provided arguments, i.e. URL("NA1", "/lol/match/v3/matches/%d", id).
+func (c *Client) URL(platform string, path string, args ...interface{}) string {
+	base := c.BaseURL
+	if base == "" {
+		base = config.DefaultAPIBaseURL
+	}
+
+	host := strings.Replace(base, "{platform}", strings.ToLower(platform), -1)
+
+	return host + fmt.Sprintf(path, args...)
+}
+
+// Get : Make a request to the Riot API and call the specified function on success. If anything
+// goes wrong with the request the first return value will provide more information (see errors.go
+// for the types of errors that can be returned); the callback is only called for successful
+// responses. If the error is rate limit related, the second value will be the number of seconds
+// you should wait before attempting another request. If the error is not rate limit-related then
+// the value of the second argument will always be 0.
+//
+// If a pacer is provided, the request is throttled using the pacer's rate limit buckets for this
+// endpoint (see Buckets()). The app and method limits that Riot reports on each response are fed
+// back into the pacer so that future requests stay within them.
+func (c *Client) Get(url string, pace *structs.Pacer, cb func(body []byte)) (error, int) {
+	client := http.Client{
+		Timeout: c.Timeout,
+	}
+
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		utils.Log(err.Error())
+		return err, 0
+	}
+
+	appBucket, methodBucket := Buckets(url)
+	if pace != nil {
+		pace.Acquire(appBucket, methodBucket)
+	}
+	request.Header.Set("X-Riot-Token", c.APIKey)
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := client.Do(request)
+
+	// Note: rate limits (420 or 429) don't cause errors but need to be handled (see below).
+	if err != nil {
+		return TransportError{URL: url, Err: err}, 0
+	}
+	defer resp.Body.Close()
+
+	if pace != nil {
+		updateLimits(pace, appBucket, resp.Header, "X-App-Rate-Limit")
+		updateLimits(pace, methodBucket, resp.Header, "X-Method-Rate-Limit")
+	}
+
+	// Check for rate limit warnings from Riot. There are two potential headers that they
+	// may send, detailed here:
+	//
+	//    https://developer.riotgames.com/rate-limiting.html
+	//
+	// We'll pause automatically any time they send `X-Rate-Limit-Type` with any value. Ideally
+	// they tell us how long to pause and we'll follow that instruction. Otherwise we'll wait for
+	// a while and try again later.
+	if resp.Header.Get("X-Rate-Limit-Type") != "" || resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := resp.Header.Get("Retry-After")
+
+		if retryAfter != "" {
+			seconds, err := strconv.Atoi(retryAfter)
+
+			if err != nil {
+				return ErrRateLimited, DefaultWaitSeconds
+			}
+
+			return ErrRateLimited, seconds
+		}
+
+		return ErrRateLimited, DefaultWaitSeconds
+	}
+
+	// Anything other than a 2xx means there's no usable body.
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return NotFoundError{URL: url}, 0
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return ForbiddenError{URL: url, Status: resp.StatusCode}, 0
+	case resp.StatusCode >= 500:
+		return ServerError{URL: url, Status: resp.StatusCode}, 0
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return StatusError{URL: url, Status: resp.StatusCode}, 0
+	}
+
+	// Decode the body first if its gzip'd.
+	src := resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		src, err = gzip.NewReader(src)
+
+		if err != nil {
+			return TransportError{URL: url, Err: err}, 0
+		}
+	}
+
+	raw, err := ioutil.ReadAll(src)
+
+	if err != nil {
+		return TransportError{URL: url, Err: err}, 0
+	}
+
+	cb(raw)
+
+	return nil, 0
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by Client.Get(). Each type describes a different way a request can fail so
+// that callers can decide whether to give up on a request (NotFoundError), try it again later
+// (ServerError, TransportError), or stop making requests entirely (ForbiddenError).
+
+// ErrRateLimited : Riot indicated that a rate limit was exceeded. Client.Get() also returns the
+// number of seconds to wait before making another request.
+var ErrRateLimited = errors.New("Rate limit exceeded; pausing...")
+
+// NotFoundError : The requested resource doesn't exist (404). Retrying won't help.
+type NotFoundError struct {
+	URL string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("Not found: %s", e.URL)
+}
+
+// ForbiddenError : Riot rejected the request (401 or 403), usually because the API key is
+// missing, expired, or blacklisted. All other requests are likely to fail as well.
+type ForbiddenError struct {
+	URL    string
+	Status int
+}
+
+func (e ForbiddenError) Error() string {
+	return fmt.Sprintf("API key rejected (%d): %s", e.Status, e.URL)
+}
+
+// ServerError : Riot returned a 5xx response. These are usually temporary.
+type ServerError struct {
+	URL    string
+	Status int
+}
+
+func (e ServerError) Error() string {
+	return fmt.Sprintf("Server error (%d): %s", e.Status, e.URL)
+}
+
+// StatusError : Riot returned an unexpected response status that isn't covered by any of the
+// other error types.
+type StatusError struct {
+	URL    string
+	Status int
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("Unexpected status (%d): %s", e.Status, e.URL)
+}
+
+// TransportError : The request couldn't be completed or the response couldn't be read, i.e.
+// because of a timeout or a dropped connection.
+type TransportError struct {
+	URL string
+	Err error
+}
+
+func (e TransportError) Error() string {
+	return fmt.Sprintf("Request failed: %s (%s)", e.URL, e.Err.Error())
+}
+
+// IsRetryable : Returns true if the error is likely temporary and the same request may succeed
+// if its attempted again later.
+func IsRetryable(err error) bool {
+	switch err.(type) {
+	case ServerError, TransportError:
+		return true
+	}
+
+	return err == ErrRateLimited
+}
@@ -1,82 +1,75 @@
 package api
 
 import (
-	"compress/gzip"
-	"errors"
-	"io/ioutil"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/anyweez/matchgrab/config"
-	"github.com/anyweez/matchgrab/utils"
+	"github.com/anyweez/matchgrab/structs"
 )
 
 // Default amount of time to wait if Riot doesn't tell us.
 const DefaultWaitSeconds = 30
 
-// Get : Make a request to the Riot API and call the specified function on success. If anything
-// goes wrong with the request the first return value will provide more information. If the error
-// is rate limit related, the second value will be the number of seconds you should wait before
-// attempting another request. If the error is not rate limit-related then the value of the second
-// argument will always be 0.
-func Get(url string, cb func(body []byte)) (error, int) {
-	client := http.Client{
-		Timeout: config.Config.HTTPTimeout,
-	}
+// Numeric path segments (match ID's, account ID's, etc) don't count towards the method name.
+var idSegment = regexp.MustCompile(`/[0-9]+(/|$)`)
 
-	request, err := http.NewRequest("GET", url, nil)
+// Buckets : Returns the names of the rate limit buckets that apply to a URL. Riot enforces an
+// application limit per platform and a method limit per endpoint on each platform, so the
+// first value is the host and the second is the host plus the path with ID's removed.
+func Buckets(rawurl string) (string, string) {
+	u, err := url.Parse(rawurl)
 	if err != nil {
-		utils.Log(err.Error())
-		return err, 0
+		return rawurl, rawurl
 	}
-	request.Header.Set("X-Riot-Token", config.Config.RiotAPIKey)
-	request.Header.Set("Accept-Encoding", "gzip")
-
-	resp, err := client.Do(request)
 
-	// Note: rate limits (420 or 429) don't cause errors but need to be handled (see below).
-	if err != nil {
-		return nil, 0
-	}
-	defer resp.Body.Close()
+	method := idSegment.ReplaceAllString(u.Path, "/{id}$1")
 
-	// Check for rate limit warnings from Riot. There are two potential headers that they
-	// may send, detailed here:
-	//
-	//    https://developer.riotgames.com/rate-limiting.html
-	//
-	// We'll pause automatically any time they send `X-Rate-Limit-Type` with any value. Ideally
-	// they tell us how long to pause and we'll follow that instruction. Otherwise we'll wait for
-	// a while and try again later.
-	if resp.Header.Get("X-Rate-Limit-Type") != "" {
-		retryAfter := resp.Header.Get("Retry-After")
+	return u.Host, u.Host + method
+}
 
-		if retryAfter != "" {
-			seconds, err := strconv.Atoi(retryAfter)
+// ParseRateLimits : Parse one of Riot's rate limit headers. Both the limit headers (i.e.
+// `X-App-Rate-Limit: 20:1,100:120`) and count headers (`X-App-Rate-Limit-Count: 1:1,1:120`)
+// are formatted as comma-separated `requests:seconds` pairs. Malformed pairs are skipped.
+func ParseRateLimits(header string) []structs.RateLimit {
+	limits := make([]structs.RateLimit, 0)
 
-			if err != nil {
-				return errors.New("Rate limit exceeded; pausing..."), DefaultWaitSeconds
-			}
+	for _, pair := range strings.Split(header, ",") {
+		parts := strings.Split(strings.TrimSpace(pair), ":")
+		if len(parts) != 2 {
+			continue
+		}
 
-			return errors.New("Rate limit exceeded; pausing..."), seconds
+		requests, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
 		}
 
-		return errors.New("Rate limit exceeded; pausing..."), DefaultWaitSeconds
-	}
+		seconds, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
 
-	// Decode the body first if its gzip'd.
-	src := resp.Body
-	if resp.Header.Get("Content-Encoding") == "gzip" {
-		src, _ = gzip.NewReader(src)
+		limits = append(limits, structs.RateLimit{
+			Requests: requests,
+			Window:   time.Duration(seconds) * time.Second,
+		})
 	}
 
-	raw, err := ioutil.ReadAll(src)
+	return limits
+}
 
-	if err != nil {
-		return err, 0
+// updateLimits : Feed the rate limit headers from a response into the pacer. Limits are
+// updated before counts so that new windows are tracked immediately.
+func updateLimits(pace *structs.Pacer, bucket string, header http.Header, prefix string) {
+	if limit := header.Get(prefix); limit != "" {
+		pace.SetLimits(bucket, ParseRateLimits(limit))
 	}
 
-	cb(raw)
-
-	return nil, 0
+	if count := header.Get(prefix + "-Count"); count != "" {
+		pace.SyncCounts(bucket, ParseRateLimits(count))
+	}
 }
@@ -2,8 +2,10 @@ package api
 
 import (
 	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -12,13 +14,15 @@ import (
 	"os"
 
 	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/riottest"
+	"github.com/anyweez/matchgrab/structs"
 )
 
 var nextPort = 12001
 
 type imitationResponse func(*http.ResponseWriter, *http.Request, []byte) []byte
 
-// Imitate the server's response and check the result of Get()
+// Imitate the server's response and check the result of Client.Get()
 func imitateServer(fn imitationResponse, chk func(e error, c int), t *testing.T) {
 	os.Setenv("RIOT_API_KEY", "abcde")
 	config.Setup()
@@ -40,7 +44,7 @@ func imitateServer(fn imitationResponse, chk func(e error, c int), t *testing.T)
 		time.Sleep(250 * time.Millisecond)
 
 		// TODO: need to eval certain errors
-		chk(Get(fmt.Sprintf("http://localhost:%d", port), func(r []byte) {
+		chk(ConfigClient().Get(fmt.Sprintf("http://localhost:%d", port), nil, func(r []byte) {
 			fmt.Println(fmt.Sprintf("Received response on %d", port))
 
 			if string(r) != "success" {
@@ -64,7 +68,7 @@ func TestBasicRequest(t *testing.T) {
 	}, noop, t)
 }
 
-// TestGzip : Ensure that Get() correctly decodes gzip-encoded information.
+// TestGzip : Ensure that Client.Get() correctly decodes gzip-encoded information.
 func TestGzip(t *testing.T) {
 	imitateServer(func(w *http.ResponseWriter, r *http.Request, msg []byte) []byte {
 		(*w).Header().Add("Content-Encoding", "gzip")
@@ -116,3 +120,205 @@ func TestRateLimitNoRetry(t *testing.T) {
 		}
 	}, t)
 }
+
+// TestParseRateLimits : Ensure Riot's rate limit headers are parsed into windows.
+func TestParseRateLimits(t *testing.T) {
+	limits := ParseRateLimits("20:1,100:120")
+
+	if len(limits) != 2 {
+		t.FailNow()
+	}
+
+	if limits[0].Requests != 20 || limits[0].Window != time.Second {
+		t.Fail()
+	}
+
+	if limits[1].Requests != 100 || limits[1].Window != 120*time.Second {
+		t.Fail()
+	}
+
+	if len(ParseRateLimits("garbage,5:x")) != 0 {
+		t.Fail()
+	}
+}
+
+// TestBuckets : Ensure ID's are removed from method bucket names.
+func TestBuckets(t *testing.T) {
+	app, method := Buckets("https://na1.api.riotgames.com/lol/match/v3/matches/12345")
+
+	if app != "na1.api.riotgames.com" {
+		t.Fail()
+	}
+
+	if method != "na1.api.riotgames.com/lol/match/v3/matches/{id}" {
+		t.Fail()
+	}
+}
+
+// TestPacedRequests : Ensure that limits reported by the server throttle later requests.
+func TestPacedRequests(t *testing.T) {
+	os.Setenv("RIOT_API_KEY", "abcde")
+	config.Setup()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-App-Rate-Limit", "100:1")
+		w.Header().Add("X-Method-Rate-Limit", "1:2")
+		w.Header().Add("X-Method-Rate-Limit-Count", "1:2")
+
+		w.Write([]byte("success"))
+	}))
+	defer srv.Close()
+
+	pace := structs.NewPacer(600, 1)
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		ConfigClient().Get(srv.URL+"/lol/match/v3/matches/1", pace, func(body []byte) {})
+	}
+
+	if time.Since(start) < 1500*time.Millisecond {
+		t.Fail()
+	}
+}
+
+// statusServer : Start a server that always responds with the specified status code.
+func statusServer(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"status": {"message": "error"}}`))
+	}))
+}
+
+// TestStatusErrors : Ensure error responses are returned as typed errors and never passed to
+// the callback.
+func TestStatusErrors(t *testing.T) {
+	os.Setenv("RIOT_API_KEY", "abcde")
+	config.Setup()
+
+	statuses := map[int]func(error) bool{
+		404: func(e error) bool { _, ok := e.(NotFoundError); return ok },
+		403: func(e error) bool { _, ok := e.(ForbiddenError); return ok },
+		503: func(e error) bool { _, ok := e.(ServerError); return ok },
+		400: func(e error) bool { _, ok := e.(StatusError); return ok },
+	}
+
+	for status, check := range statuses {
+		srv := statusServer(status)
+
+		err, _ := ConfigClient().Get(srv.URL, nil, func(body []byte) {
+			t.Errorf("callback called for %d", status)
+		})
+
+		if !check(err) {
+			t.Errorf("wrong error type for %d: %v", status, err)
+		}
+
+		srv.Close()
+	}
+}
+
+// TestTransportError : Ensure failed connections are reported rather than ignored.
+func TestTransportError(t *testing.T) {
+	os.Setenv("RIOT_API_KEY", "abcde")
+	config.Setup()
+
+	srv := statusServer(200)
+	srv.Close()
+
+	err, _ := ConfigClient().Get(srv.URL, nil, func(body []byte) {
+		t.Fail()
+	})
+
+	if _, ok := err.(TransportError); !ok || !IsRetryable(err) {
+		t.Fail()
+	}
+}
+
+// TestBaseURL : Ensure Client.URL() uses the configured base URL for every platform.
+func TestBaseURL(t *testing.T) {
+	os.Setenv("RIOT_API_KEY", "abcde")
+	config.Setup()
+
+	if ConfigClient().URL("EUW1", "/lol/match/v3/matches/%d", 5) != "https://euw1.api.riotgames.com/lol/match/v3/matches/5" {
+		t.Fail()
+	}
+
+	config.Config.APIBaseURL = "http://127.0.0.1:8080"
+	defer config.Setup()
+
+	if ConfigClient().URL("EUW1", "/lol/match/v3/matches/%d", 5) != "http://127.0.0.1:8080/lol/match/v3/matches/5" {
+		t.Fail()
+	}
+}
+
+// TestMatchlistURL : Ensure only the parameters that are set are sent, and lists are repeated.
+func TestMatchlistURL(t *testing.T) {
+	client := NewClient("abcde")
+	client.BaseURL = "http://127.0.0.1:8080"
+
+	if client.MatchlistURL("NA1", 7, MatchlistQuery{}) != "http://127.0.0.1:8080/lol/match/v3/matchlists/by-account/7" {
+		t.Error("empty query added parameters")
+	}
+
+	url := client.MatchlistURL("NA1", 7, MatchlistQuery{
+		BeginTime:  1500000000000,
+		BeginIndex: 100,
+		EndIndex:   200,
+		Queues:     []int{420, 440},
+	})
+
+	expected := "http://127.0.0.1:8080/lol/match/v3/matchlists/by-account/7" +
+		"?beginIndex=100&beginTime=1500000000000&endIndex=200&queue=420&queue=440"
+
+	if url != expected {
+		t.Errorf("unexpected url %s", url)
+	}
+}
+
+// TestFakeAPI : Fetch a matchlist and its matches from a riottest server, including an injected
+// rate limit.
+func TestFakeAPI(t *testing.T) {
+	os.Setenv("RIOT_API_KEY", "abcde")
+	config.Setup()
+
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", RateLimitEvery: 4, RetryAfter: 3})
+	defer srv.Close()
+
+	config.Config.APIBaseURL = srv.URL
+	defer config.Setup()
+
+	summaries := struct {
+		Matches []structs.MatchSummary `json:"matches"`
+	}{}
+
+	client := ConfigClient()
+
+	err, _ := client.Get(client.URL("NA1", "/lol/match/v3/matchlists/by-account/%d", srv.SeedAccount()), nil, func(body []byte) {
+		json.Unmarshal(body, &summaries)
+	})
+	if err != nil || len(summaries.Matches) == 0 {
+		t.Fatalf("couldn't fetch matchlist: %v", err)
+	}
+
+	limited := 0
+	for _, summary := range summaries.Matches[:3] {
+		err, wait := client.Get(client.URL("NA1", "/lol/match/v3/matches/%d", summary.GameID), nil, func(body []byte) {
+			var raw structs.APIMatch
+			json.Unmarshal(body, &raw)
+
+			if raw.GameID != summary.GameID {
+				t.Errorf("expected match %d, got %d", summary.GameID, raw.GameID)
+			}
+		})
+
+		if err == ErrRateLimited && wait == 3 {
+			limited++
+		} else if err != nil {
+			t.Error(err)
+		}
+	}
+
+	if limited != 1 {
+		t.Errorf("expected one rate limited request, got %d", limited)
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// MatchlistPageSize : Riot never returns more than this many matches in one matchlist response.
+const MatchlistPageSize = 100
+
+// MatchlistQuery : Optional parameters for the matchlist endpoint. Zero values are left out of
+// the request. Riot only allows one week between BeginTime and EndTime when both are provided,
+// and MatchlistPageSize between BeginIndex and EndIndex.
+type MatchlistQuery struct {
+	BeginTime int64 // epoch milliseconds, inclusive
+	EndTime   int64 // epoch milliseconds, inclusive
+
+	BeginIndex int
+	EndIndex   int
+
+	Queues  []int
+	Seasons []int
+}
+
+// Values : Encode the query as URL parameters.
+func (q MatchlistQuery) Values() url.Values {
+	v := url.Values{}
+
+	if q.BeginTime != 0 {
+		v.Set("beginTime", strconv.FormatInt(q.BeginTime, 10))
+	}
+	if q.EndTime != 0 {
+		v.Set("endTime", strconv.FormatInt(q.EndTime, 10))
+	}
+	if q.BeginIndex != 0 {
+		v.Set("beginIndex", strconv.Itoa(q.BeginIndex))
+	}
+	if q.EndIndex != 0 {
+		v.Set("endIndex", strconv.Itoa(q.EndIndex))
+	}
+
+	for _, queue := range q.Queues {
+		v.Add("queue", strconv.Itoa(queue))
+	}
+	for _, season := range q.Seasons {
+		v.Add("season", strconv.Itoa(season))
+	}
+
+	return v
+}
+
+// MatchlistURL : Build the URL for a page of an account's matchlist.
+func (c *Client) MatchlistURL(platform string, account structs.RiotID, q MatchlistQuery) string {
+	u := c.URL(platform, "/lol/match/v3/matchlists/by-account/%d", account)
+
+	if params := q.Values().Encode(); params != "" {
+		u += "?" + params
+	}
+
+	return u
+}
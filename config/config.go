@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -12,15 +13,51 @@ type config struct {
 	MatchStoreLocation string        `json:"match_store_location"`
 	SeedAccount        int64         `json:"seed_account"`
 
+	// Platforms to crawl concurrently (NA1, EUW1, KR, etc). Each platform gets its own
+	// frontier and pacer. SeedAccounts can provide a per-platform seed; platforms without
+	// one fall back to SeedAccount.
+	Platforms    []string         `json:"platforms"`
+	SeedAccounts map[string]int64 `json:"seed_accounts"`
+
+	// Base URL for all API requests. `{platform}` is replaced with the lowercase platform ID.
+	// Point this at a riottest server to crawl without hitting Riot.
+	APIBaseURL string `json:"api_base_url"`
+
 	MaxSimultaneousRequests int           `json:"max_sim_requests"`
 	RequestsPerMinute       int           `json:"requests_per_min"`
 	MaxTimeAgo              time.Duration `json:"max_time_ago"`
+	RevisitInterval         time.Duration `json:"revisit_interval"` // zero never revisits summoners
 	RiotAPIKey              string        `json:"riot_api_key"`
 	KeepStats               bool          `json:"keep_stats"`
+	Timelines               bool          `json:"timelines"`   // also fetch each match's timeline
+	ArchiveRaw              bool          `json:"archive_raw"` // keep raw API responses for `grab reprocess`
+
+	// How often the match store is snapshotted (zero never does) and how many snapshots to keep.
+	SnapshotInterval    time.Duration `json:"snapshot_interval"`
+	SnapshotGenerations int           `json:"snapshot_generations"`
+
+	// How crawl queues remember which ID's they've seen: "bloom" (in memory, but skips a small
+	// fraction of ID's) or "exact" (on disk).
+	Dedup string `json:"dedup"`
+
+	Filters MatchFilters `json:"filters"`
+}
+
+// MatchFilters : Which matches get crawled and stored. Each field lists the values that are
+// allowed; empty fields allow everything.
+type MatchFilters struct {
+	Queues    []int    `json:"queues"`
+	Seasons   []int    `json:"seasons"`
+	Maps      []int    `json:"maps"`
+	GameModes []string `json:"game_modes"`
+	GameTypes []string `json:"game_types"`
 }
 
 var Config config
 
+// DefaultAPIBaseURL : Riot's production API. Each platform has its own host.
+const DefaultAPIBaseURL = "https://{platform}.api.riotgames.com"
+
 func Setup() {
 	// Check if there's a config file specified. If so, we should use those settings.
 	raw, err := ioutil.ReadFile("config.json")
@@ -30,11 +67,20 @@ func Setup() {
 		HTTPTimeout:             20 * time.Second,
 		MatchStoreLocation:      "/Volumes/LOLMatches/matches.db",
 		SeedAccount:             50669460,
+		Platforms:               []string{"NA1"},
+		SeedAccounts:            make(map[string]int64),
+		APIBaseURL:              DefaultAPIBaseURL,
 		MaxSimultaneousRequests: 6,
 		RequestsPerMinute:       480,
 		MaxTimeAgo:              time.Duration(60 * 24 * time.Hour), // 60 days
+		RevisitInterval:         time.Duration(7 * 24 * time.Hour),  // 1 week
 		RiotAPIKey:              "",
 		KeepStats:               false,
+		Timelines:               false,
+		ArchiveRaw:              false,
+		SnapshotInterval:        time.Duration(1 * time.Hour),
+		SnapshotGenerations:     2,
+		Dedup:                   "bloom",
 	}
 
 	// TODO: probably a cleaner way to do this; need to find golang pattern
@@ -44,11 +90,26 @@ func Setup() {
 			MatchStoreLocation string `json:"match_store_location"`
 			SeedAccount        int64  `json:"seed_account"`
 
+			Platforms    []string         `json:"platforms"`
+			SeedAccounts map[string]int64 `json:"seed_accounts"`
+
+			APIBaseURL string `json:"api_base_url"`
+
 			MaxSimultaneousRequests int    `json:"max_sim_requests"`
 			RequestsPerMinute       int    `json:"requests_per_min"`
 			MaxTimeAgo              string `json:"max_time_ago"`
+			RevisitInterval         string `json:"revisit_interval"`
 			RiotAPIKey              string `json:"riot_api_key"`
 			KeepStats               bool   `json:"keep_stats"`
+			Timelines               bool   `json:"timelines"`
+			ArchiveRaw              bool   `json:"archive_raw"`
+
+			SnapshotInterval    string `json:"snapshot_interval"`
+			SnapshotGenerations int    `json:"snapshot_generations"`
+
+			Dedup string `json:"dedup"`
+
+			Filters MatchFilters `json:"filters"`
 		}{}
 
 		json.Unmarshal(raw, &specified)
@@ -68,6 +129,26 @@ func Setup() {
 			defaults.MatchStoreLocation = specified.MatchStoreLocation
 		}
 
+		if specified.SeedAccount != 0 {
+			defaults.SeedAccount = specified.SeedAccount
+		}
+
+		if len(specified.Platforms) > 0 {
+			defaults.Platforms = make([]string, 0, len(specified.Platforms))
+
+			for _, platform := range specified.Platforms {
+				defaults.Platforms = append(defaults.Platforms, NormalizePlatform(platform))
+			}
+		}
+
+		for platform, account := range specified.SeedAccounts {
+			defaults.SeedAccounts[NormalizePlatform(platform)] = account
+		}
+
+		if specified.APIBaseURL != "" {
+			defaults.APIBaseURL = strings.TrimRight(specified.APIBaseURL, "/")
+		}
+
 		if specified.MaxSimultaneousRequests != 0 {
 			defaults.MaxSimultaneousRequests = specified.MaxSimultaneousRequests
 		}
@@ -87,11 +168,49 @@ func Setup() {
 			defaults.MaxTimeAgo = timeago
 		}
 
+		// Replace RevisitInterval if its present (parse first!)
+		if specified.RevisitInterval != "" {
+			interval, err := time.ParseDuration(specified.RevisitInterval)
+
+			if err != nil {
+				panic(err)
+			}
+
+			defaults.RevisitInterval = interval
+		}
+
+		// Replace SnapshotInterval if its present (parse first!)
+		if specified.SnapshotInterval != "" {
+			interval, err := time.ParseDuration(specified.SnapshotInterval)
+
+			if err != nil {
+				panic(err)
+			}
+
+			defaults.SnapshotInterval = interval
+		}
+
+		if specified.SnapshotGenerations > 0 {
+			defaults.SnapshotGenerations = specified.SnapshotGenerations
+		}
+
 		if specified.RiotAPIKey != "" {
 			defaults.RiotAPIKey = specified.RiotAPIKey
 		}
 
 		defaults.KeepStats = specified.KeepStats
+		defaults.Timelines = specified.Timelines
+		defaults.ArchiveRaw = specified.ArchiveRaw
+
+		defaults.Filters = specified.Filters
+
+		switch specified.Dedup {
+		case "":
+		case "bloom", "exact":
+			defaults.Dedup = specified.Dedup
+		default:
+			panic("Unknown dedup mode: " + specified.Dedup)
+		}
 	}
 
 	if os.Getenv("RIOT_API_KEY") != "" {
@@ -104,3 +223,18 @@ func Setup() {
 		panic("No RIOT_API_KEY specified; cannot continue.")
 	}
 }
+
+// NormalizePlatform : Platform ID's are stored and compared in their canonical uppercase
+// form (NA1, EUW1, KR) regardless of how they were specified.
+func NormalizePlatform(platform string) string {
+	return strings.ToUpper(strings.TrimSpace(platform))
+}
+
+// SeedFor : Returns the seed account for the specified platform.
+func (c config) SeedFor(platform string) int64 {
+	if seed, exists := c.SeedAccounts[NormalizePlatform(platform)]; exists {
+		return seed
+	}
+
+	return c.SeedAccount
+}
@@ -0,0 +1,804 @@
+// Package crawler downloads matches from a single Riot platform. Crawlers start from a set of
+// seed accounts, request each account's recent matches, and then request the accounts of
+// everyone that played in those matches, and so on until they're stopped.
+//
+// Crawlers don't depend on any global state so any number of them can run in the same process;
+// matchgrab runs one per platform.
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/anyweez/matchgrab/api"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// Defaults used for options that aren't provided.
+const (
+	DefaultRequestsPerMinute       = 480
+	DefaultMaxSimultaneousRequests = 6
+	DefaultMaxAttempts             = 5
+	DefaultRetryBackoff            = 30 * time.Second
+	DefaultSaveInterval            = 5 * time.Minute
+)
+
+// Filter : Decides whether a match should be kept. Matches that any filter rejects aren't
+// stored, but their participants are still crawled.
+type Filter func(m *structs.Match) bool
+
+// Options : Everything a crawler needs. Only Store is required.
+type Options struct {
+	Platform string           // platform to crawl (default NA1)
+	Seeds    []structs.RiotID // accounts to start crawling from
+
+	Store    *structs.MatchStore
+	Client   *api.Client    // default is built from the application config
+	Pacer    *structs.Pacer // should not be shared with other crawlers
+	Reporter Reporter       // receives events and progress updates
+	Filters  []Filter
+
+	// Checked before each match in a matchlist is queued. See Selection for a way to build
+	// both kinds of filters from the same description.
+	SummaryFilters []SummaryFilter
+
+	// Only request matchlist entries from these queues and seasons. Unlike SummaryFilters,
+	// these are sent to the API so that unwanted matches don't take up any pages. Empty lists
+	// request everything.
+	Queues  []int
+	Seasons []int
+
+	// Matchlist entries older than this aren't requested. Zero means no limit.
+	MaxTimeAgo time.Duration
+
+	// Summoners are crawled again once this much time has passed since their last crawl, and
+	// only matches newer than the ones already seen are requested. Zero means each summoner is
+	// only crawled once.
+	RevisitInterval time.Duration
+
+	// Failed requests are retried up to MaxAttempts times, waiting twice as long after each
+	// attempt starting with RetryBackoff. ID's that fail every attempt are recorded as dead
+	// letters in the store.
+	MaxAttempts  int
+	RetryBackoff time.Duration
+
+	// How often the match and summoner queues are saved to the store while running. They're
+	// also saved when Run() returns; see Save() and Resume().
+	SaveInterval time.Duration
+
+	// Directory for queue entries that don't fit in memory (see IDList.SpillTo()). Defaults
+	// to the store's location plus "-frontier".
+	SpillDir string
+
+	// How queues remember what they've already seen; structs.DedupBloom (default) or
+	// structs.DedupExact. Exact records are kept in SpillDir.
+	Dedup string
+
+	// Also request the timeline for each stored match; see MatchStore.GetTimeline(). Each
+	// timeline takes an extra request.
+	Timelines bool
+
+	// Keep the raw API response for each stored match so it can be converted again later; see
+	// MatchStore.Archive() and MatchStore.Reprocess().
+	Archive bool
+}
+
+// Crawler : Crawl state for a single platform. Create one with New().
+type Crawler struct {
+	opts Options
+
+	matches   *structs.IDList
+	summoners *structs.IDList
+
+	// Failed requests are retried from here before anything new is requested.
+	matchRetries    *structs.RetryQueue
+	summonerRetries *structs.RetryQueue
+	timelineRetries *structs.RetryQueue
+
+	onMatch []func(m *structs.Match)
+	onError []func(err error)
+	cbLock  sync.Mutex
+
+	// Summoners that have been queued for a revisit but not requested yet; see revisit().
+	revisiting  map[structs.RiotID]bool
+	revisitLock sync.Mutex
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	running  sync.RWMutex // held for reading by each request; see Run()
+}
+
+// RequestError : A request that failed. Err is one of the errors from the api package.
+type RequestError struct {
+	Platform string
+	Kind     string // structs.DeadMatch, structs.DeadSummoner, or structs.DeadTimeline
+	ID       structs.RiotID
+	Err      error
+}
+
+func (e RequestError) Error() string {
+	return fmt.Sprintf("%s %s %d: %s", e.Platform, e.Kind, e.ID, e.Err.Error())
+}
+
+// New : Create a crawler. Panics if no store is provided or the spill directory can't be
+// opened.
+func New(opts Options) *Crawler {
+	if opts.Store == nil {
+		panic("crawler: Options.Store is required")
+	}
+
+	if opts.Platform == "" {
+		opts.Platform = structs.LegacyPlatform
+	}
+	if opts.Client == nil {
+		opts.Client = api.ConfigClient()
+	}
+	if opts.Pacer == nil {
+		opts.Pacer = structs.NewPacer(DefaultRequestsPerMinute, DefaultMaxSimultaneousRequests)
+	}
+	if opts.Reporter == nil {
+		opts.Reporter = nopReporter{}
+	}
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.RetryBackoff == 0 {
+		opts.RetryBackoff = DefaultRetryBackoff
+	}
+	if opts.SaveInterval == 0 {
+		opts.SaveInterval = DefaultSaveInterval
+	}
+	if opts.SpillDir == "" {
+		opts.SpillDir = opts.Store.Location() + "-frontier"
+	}
+	if opts.Dedup == "" {
+		opts.Dedup = structs.DedupBloom
+	}
+
+	c := &Crawler{
+		opts:            opts,
+		matches:         structs.NewIDList(),
+		summoners:       structs.NewIDList(),
+		matchRetries:    structs.NewRetryQueue(opts.MaxAttempts, opts.RetryBackoff),
+		summonerRetries: structs.NewRetryQueue(opts.MaxAttempts, opts.RetryBackoff),
+		timelineRetries: structs.NewRetryQueue(opts.MaxAttempts, opts.RetryBackoff),
+		revisiting:      make(map[structs.RiotID]bool),
+		stop:            make(chan struct{}),
+	}
+
+	if err := c.openLists(c.matches, c.summoners); err != nil {
+		panic("crawler: " + err.Error())
+	}
+
+	for _, seed := range opts.Seeds {
+		c.summoners.Add(seed)
+	}
+
+	return c
+}
+
+// Platform : Returns the platform this crawler requests from.
+func (c *Crawler) Platform() string {
+	return c.opts.Platform
+}
+
+// OnMatch : Register a function to call after each new match is stored.
+func (c *Crawler) OnMatch(fn func(m *structs.Match)) {
+	c.cbLock.Lock()
+	defer c.cbLock.Unlock()
+
+	c.onMatch = append(c.onMatch, fn)
+}
+
+// OnError : Register a function to call whenever a request fails. Errors are RequestErrors.
+func (c *Crawler) OnError(fn func(err error)) {
+	c.cbLock.Lock()
+	defer c.cbLock.Unlock()
+
+	c.onError = append(c.onError, fn)
+}
+
+// Restore : Mark a match that's already in the store as crawled and queue its participants.
+// Call this for existing matches before Run() so they aren't requested again. Not needed if
+// Resume() succeeds.
+func (c *Crawler) Restore(m *structs.Match) {
+	c.matches.Blacklist(m.GameID)
+	c.addSummoners(m)
+}
+
+// Shuffle : Shuffle queued summoners so that crawls don't start with the same group every time.
+func (c *Crawler) Shuffle() {
+	c.summoners.Shuffle()
+}
+
+// Save : Write the match and summoner queues, including the record of everything that's
+// already been queued, to the store. ID's waiting to be retried are saved as well, since
+// they've already left the queues.
+func (c *Crawler) Save() error {
+	if err := c.opts.Store.SaveFrontier(c.opts.Platform, structs.FrontierMatches, c.matches); err != nil {
+		return err
+	}
+
+	if err := c.opts.Store.SaveFrontier(c.opts.Platform, structs.FrontierSummoners, c.summoners); err != nil {
+		return err
+	}
+
+	for kind, rq := range c.retryQueues() {
+		if err := c.opts.Store.SaveRetries(c.opts.Platform, kind, rq); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retryQueues : Returns each retry queue keyed by the kind of request it holds.
+func (c *Crawler) retryQueues() map[string]*structs.RetryQueue {
+	return map[string]*structs.RetryQueue{
+		structs.DeadMatch:    c.matchRetries,
+		structs.DeadSummoner: c.summonerRetries,
+		structs.DeadTimeline: c.timelineRetries,
+	}
+}
+
+// Resume : Replace the match and summoner queues with the ones last saved to the store, along
+// with the retries that were pending when they were saved. Returns false if nothing has been
+// saved for this platform, in which case existing matches should be loaded with Restore()
+// instead. Must be called before Run().
+func (c *Crawler) Resume() (bool, error) {
+	matches, err := c.opts.Store.LoadFrontier(c.opts.Platform, structs.FrontierMatches)
+	if err != nil || matches == nil {
+		return false, err
+	}
+
+	summoners, err := c.opts.Store.LoadFrontier(c.opts.Platform, structs.FrontierSummoners)
+	if err != nil || summoners == nil {
+		return false, err
+	}
+
+	// The spill and exact databases can only be open once, so release them from the current
+	// lists.
+	c.matches.Close()
+	c.summoners.Close()
+
+	if err := c.openLists(matches, summoners); err != nil {
+		return false, err
+	}
+
+	// Seeds may already have been crawled, in which case this is a no-op.
+	for _, seed := range c.opts.Seeds {
+		summoners.Add(seed)
+	}
+
+	c.matches = matches
+	c.summoners = summoners
+
+	for kind, rq := range c.retryQueues() {
+		if err := c.opts.Store.LoadRetries(c.opts.Platform, kind, rq); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// openLists : Let the match and summoner lists spill to disk once they're full, and switch them
+// to exact deduplication if it was requested.
+func (c *Crawler) openLists(matches *structs.IDList, summoners *structs.IDList) error {
+	lists := map[string]*structs.IDList{
+		structs.FrontierMatches:   matches,
+		structs.FrontierSummoners: summoners,
+	}
+
+	for name, list := range lists {
+		path := filepath.Join(c.opts.SpillDir, c.opts.Platform+"-"+name)
+
+		if err := list.SpillTo(path); err != nil {
+			return err
+		}
+
+		if c.opts.Dedup == structs.DedupExact {
+			if err := list.ExactIn(path + "-seen"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// QueueStats : Returns stats for the match and summoner queues, including how likely they are
+// to reject new ID's.
+func (c *Crawler) QueueStats() (structs.IDListStats, structs.IDListStats) {
+	return c.matches.Stats(), c.summoners.Stats()
+}
+
+// Replay : Queue a dead letter to be retried. Returns false if the dead letter belongs to a
+// different platform.
+func (c *Crawler) Replay(dl structs.DeadLetter) bool {
+	if dl.Platform != c.opts.Platform {
+		return false
+	}
+
+	switch dl.Kind {
+	case structs.DeadMatch:
+		c.matchRetries.Add(dl.ID)
+	case structs.DeadSummoner:
+		c.summonerRetries.Add(dl.ID)
+	case structs.DeadTimeline:
+		c.timelineRetries.Add(dl.ID)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Run : Crawl until the context is cancelled or Stop() is called. Run() waits for requests
+// that are in progress to finish and saves the queues before returning. A crawler can't be
+// restarted once it stops.
+func (c *Crawler) Run(ctx context.Context) error {
+	go func() {
+		save := time.NewTicker(c.opts.SaveInterval)
+		defer save.Stop()
+
+		// Check for summoners to revisit a few times per interval so none of them are too late.
+		var revisit <-chan time.Time
+		if c.opts.RevisitInterval > 0 {
+			ticker := time.NewTicker(c.opts.RevisitInterval / 4)
+			defer ticker.Stop()
+
+			revisit = ticker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				c.Stop()
+				return
+			case <-c.stop:
+				return
+			case <-save.C:
+				if err := c.Save(); err != nil {
+					c.event("Couldn't save queues: " + err.Error())
+				}
+			case <-revisit:
+				if err := c.revisit(); err != nil {
+					c.event("Couldn't check for summoners to revisit: " + err.Error())
+				}
+			}
+		}
+	}()
+
+	c.opts.Pacer.Run(c.step, 0)
+
+	// Wait for in-progress requests.
+	c.running.Lock()
+	c.running.Unlock()
+
+	err := c.Save()
+
+	c.matches.Close()
+	c.summoners.Close()
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+// Stop : Stop crawling. Safe to call more than once.
+func (c *Crawler) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		c.opts.Pacer.Close()
+	})
+}
+
+// stopped : Returns true once Stop() has been called.
+func (c *Crawler) stopped() bool {
+	select {
+	case <-c.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// step : Make the next request. Failed requests are retried first, then matches and summoners
+// are chosen in proportion to how full their queues are.
+func (c *Crawler) step() {
+	c.running.RLock()
+	defer c.running.RUnlock()
+
+	if c.stopped() {
+		return
+	}
+
+	wait := 0
+
+	if id, ready := c.matchRetries.Next(); ready { // Retry failed match
+		wait = c.requestMatch(id)
+	} else if id, ready := c.summonerRetries.Next(); ready { // Retry failed summoner
+		wait = c.requestSummoner(id)
+	} else if id, ready := c.timelineRetries.Next(); ready { // Retry failed timeline
+		wait = c.requestTimeline(id)
+	} else if rand.Float32() < c.matches.Filled() { // Request match
+		wait = c.getMatch()
+	} else if c.summoners.Available() { // Request summoner games
+		wait = c.getSummoner()
+	} else if c.matches.Available() { // fallback if no summoners are available
+		wait = c.getMatch()
+	}
+
+	// If the selected function instructed us to pause, do it.
+	if wait > 0 {
+		c.opts.Pacer.PauseFor(time.Duration(wait) * time.Second)
+	}
+}
+
+// addSummoners : Add all participants in a match to the summoner queue.
+func (c *Crawler) addSummoners(m *structs.Match) {
+	for _, p := range m.Participants {
+		// Some duplicates (fine), many new folks as well
+		c.summoners.Add(p.AccountID)
+	}
+}
+
+// getMatch : Fetches match information for the next match in the queue and adds it to
+// the match store. Returns the number of seconds to wait before making another request
+// (usually zero unless a rate limit was encountered).
+func (c *Crawler) getMatch() int {
+	match, available := c.matches.Next()
+	if !available {
+		c.eventf("[ Match  ] %s queue empty, skipping...", c.opts.Platform)
+		return 0
+	}
+
+	return c.requestMatch(match)
+}
+
+// requestMatch : Fetches and stores a specific match, along with its timeline if Timelines is
+// set. Failed requests are queued to be retried later; see retry().
+func (c *Crawler) requestMatch(match structs.RiotID) int {
+	c.eventf("[ Match  ] Fetching %s %d...", c.opts.Platform, match)
+
+	url := c.opts.Client.URL(c.opts.Platform, "/lol/match/v3/matches/%d", match)
+	stored := false
+
+	err, wait := c.fetch(url, func(body []byte) {
+		var full structs.APIMatch
+		err := json.Unmarshal(body, &full)
+
+		// Never store a record we couldn't make sense of.
+		if err != nil || full.GameID == 0 {
+			c.eventf("[ Match  ] Couldn't decode %s %d, skipping...", c.opts.Platform, match)
+			return
+		}
+
+		match := structs.ToMatch(full)
+		match.PlatformID = c.opts.Platform
+
+		// Add all account ID's to the summoner queue, even if the match itself isn't kept.
+		c.addSummoners(&match)
+		c.progress()
+
+		if !c.keep(&match) {
+			c.eventf("[ Match  ] Filtered %s %d", c.opts.Platform, match.GameID)
+			return
+		}
+
+		c.opts.Store.Add(match)
+
+		if c.opts.Archive {
+			if err := c.opts.Store.Archive(c.opts.Platform, match.GameID, body); err != nil {
+				c.event("Couldn't archive match: " + err.Error())
+			}
+		}
+
+		c.matchStored(&match)
+		stored = true
+	})
+
+	// Matches that don't exist are skipped permanently; they're already blacklisted.
+	if _, missing := err.(api.NotFoundError); missing {
+		c.eventf("[ Match  ] %s %d not found, skipping...", c.opts.Platform, match)
+	} else if err != nil {
+		c.event(err.Error())
+	}
+
+	c.retry(c.matchRetries, structs.DeadMatch, match, err)
+
+	// Keep the longer pause so a rate limit on either request is respected.
+	if stored && c.opts.Timelines {
+		// The timeline is a separate request, so it waits for its own slot.
+		if !c.opts.Pacer.Wait() {
+			c.timelineRetries.Requeue(match)
+			return wait
+		}
+
+		if timelineWait := c.requestTimeline(match); timelineWait > wait {
+			wait = timelineWait
+		}
+	}
+
+	return wait
+}
+
+// requestTimeline : Fetches and stores the timeline for a match. Failed requests are queued to
+// be retried later; see retry().
+func (c *Crawler) requestTimeline(match structs.RiotID) int {
+	c.eventf("[Timeline] Fetching %s %d...", c.opts.Platform, match)
+
+	url := c.opts.Client.URL(c.opts.Platform, "/lol/match/v3/timelines/by-match/%d", match)
+
+	err, wait := c.fetch(url, func(body []byte) {
+		var raw structs.APITimeline
+
+		if err := json.Unmarshal(body, &raw); err != nil || len(raw.Frames) == 0 {
+			c.eventf("[Timeline] Couldn't decode %s %d, skipping...", c.opts.Platform, match)
+			return
+		}
+
+		if err := c.opts.Store.AddTimeline(structs.ToTimeline(c.opts.Platform, match, raw)); err != nil {
+			c.event("Couldn't store timeline: " + err.Error())
+		}
+	})
+
+	if err != nil {
+		c.event(err.Error())
+	}
+
+	c.retry(c.timelineRetries, structs.DeadTimeline, match, err)
+
+	return wait
+}
+
+// Fetch a new set of match ID's for a new summoner. All returned match ID's are queued
+// up for future requests.
+func (c *Crawler) getSummoner() int {
+	summoner, available := c.summoners.Next()
+	if !available {
+		c.eventf("[Summoner] %s queue empty, skipping...", c.opts.Platform)
+		return 0
+	}
+
+	return c.requestSummoner(summoner)
+}
+
+// requestSummoner : Fetches and queues matches for a specific summoner, one page at a time.
+// Only matches within MaxTimeAgo are requested, and summoners that have been crawled before
+// only get matches newer than the last ones that were seen. Failed requests are queued to be
+// retried later; see retry().
+func (c *Crawler) requestSummoner(summoner structs.RiotID) int {
+	c.revisitLock.Lock()
+	delete(c.revisiting, summoner)
+	c.revisitLock.Unlock()
+
+	record, revisit, err := c.opts.Store.Summoner(c.opts.Platform, summoner)
+	if err != nil {
+		c.event("Couldn't look up summoner: " + err.Error())
+	}
+
+	record.Platform = c.opts.Platform
+	record.ID = summoner
+	record.LastCrawled = time.Now()
+
+	query := api.MatchlistQuery{
+		Queues:  c.opts.Queues,
+		Seasons: c.opts.Seasons,
+	}
+
+	if c.opts.MaxTimeAgo > 0 {
+		query.BeginTime = toMillis(record.LastCrawled.Add(-c.opts.MaxTimeAgo))
+	}
+
+	if revisit && record.LatestMatch >= query.BeginTime {
+		c.eventf("[Summoner] Revisiting %s %d...", c.opts.Platform, summoner)
+		query.BeginTime = record.LatestMatch + 1
+	} else {
+		c.eventf("[Summoner] Fetching %s %d...", c.opts.Platform, summoner)
+	}
+
+	// Matchlists are newest first. The API may ignore a beginTime without an endTime, so
+	// anything older is skipped here as well, and paging stops once a page reaches it.
+	cutoff := query.BeginTime
+	wait := 0
+
+	for {
+		// Every page after the first is a separate request, so it waits for its own slot.
+		if query.BeginIndex > 0 && !c.opts.Pacer.Wait() {
+			c.summonerRetries.Requeue(summoner)
+			return wait
+		}
+
+		query.EndIndex = query.BeginIndex + api.MatchlistPageSize
+		list := structs.Matchlist{}
+		decoded := false
+
+		err, wait = c.fetch(c.opts.Client.MatchlistURL(c.opts.Platform, summoner, query), func(body []byte) {
+			if err := json.Unmarshal(body, &list); err != nil {
+				c.eventf("[Summoner] Couldn't decode %s %d, skipping...", c.opts.Platform, summoner)
+				return
+			}
+
+			decoded = true
+		})
+
+		// Riot responds with a 404 when there aren't any matches in the requested range, i.e.
+		// when a summoner hasn't played since their last visit.
+		if _, missing := err.(api.NotFoundError); missing {
+			err = nil
+			break
+		}
+
+		if err != nil || !decoded {
+			break
+		}
+
+		past := false
+		for _, match := range list.Matches {
+			// Too old, or seen on a previous visit.
+			if match.Timestamp < cutoff {
+				past = true
+				continue
+			}
+
+			if match.Timestamp > record.LatestMatch {
+				record.LatestMatch = match.Timestamp
+			}
+
+			if c.keepSummary(match) {
+				c.matches.Add(match.GameID)
+			}
+		}
+
+		c.progress()
+
+		last := len(list.Matches) < api.MatchlistPageSize || list.EndIndex >= list.TotalGames
+		if last || past || list.EndIndex <= query.BeginIndex {
+			break
+		}
+
+		query.BeginIndex = list.EndIndex
+	}
+
+	if err != nil {
+		c.event(err.Error())
+	} else if err := c.opts.Store.SetSummoner(record); err != nil {
+		c.event("Couldn't record summoner: " + err.Error())
+	}
+
+	c.retry(c.summonerRetries, structs.DeadSummoner, summoner, err)
+
+	return wait
+}
+
+// toMillis : Convert a time to epoch milliseconds, the way Riot represents timestamps.
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// revisit : Queue every summoner whose last crawl was more than RevisitInterval ago. Summoners
+// that are already waiting for a revisit aren't queued again.
+func (c *Crawler) revisit() error {
+	due := time.Now().Add(-c.opts.RevisitInterval)
+	queued := 0
+
+	err := c.opts.Store.Summoners(c.opts.Platform, func(sr structs.SummonerRecord) {
+		if sr.LastCrawled.After(due) {
+			return
+		}
+
+		c.revisitLock.Lock()
+		defer c.revisitLock.Unlock()
+
+		if !c.revisiting[sr.ID] && c.summoners.Requeue(sr.ID) {
+			c.revisiting[sr.ID] = true
+			queued++
+		}
+	})
+
+	if queued > 0 {
+		c.eventf("[Summoner] Queued %d %s summoners to revisit", queued, c.opts.Platform)
+		c.progress()
+	}
+
+	return err
+}
+
+// fetch : Request a URL from this platform's API host. If Riot rejects the API key the crawler
+// pauses for a while since all other requests are going to fail as well.
+func (c *Crawler) fetch(url string, cb func(body []byte)) (error, int) {
+	err, wait := c.opts.Client.Get(url, c.opts.Pacer, cb)
+	c.opts.Reporter.Request(c.opts.Platform)
+
+	if _, forbidden := err.(api.ForbiddenError); forbidden {
+		return err, api.DefaultWaitSeconds
+	}
+
+	return err, wait
+}
+
+// retry : Queue up another attempt for an ID if its request failed with a temporary error. ID's
+// that run out of attempts are recorded as dead letters so they can be replayed later.
+func (c *Crawler) retry(rq *structs.RetryQueue, kind string, id structs.RiotID, err error) {
+	if err != nil {
+		c.requestFailed(RequestError{Platform: c.opts.Platform, Kind: kind, ID: id, Err: err})
+	}
+
+	if err == nil || !api.IsRetryable(err) {
+		rq.Succeed(id)
+		return
+	}
+
+	// Rate limits say nothing about the request itself, so they don't use up an attempt. The
+	// pacer pauses before anything else is requested.
+	if err == api.ErrRateLimited {
+		rq.Requeue(id)
+		return
+	}
+
+	attempts, retrying := rq.Fail(id)
+	if retrying {
+		return
+	}
+
+	c.eventf("[ Retry  ] Giving up on %s %s %d", c.opts.Platform, kind, id)
+	c.opts.Store.AddDeadLetter(structs.DeadLetter{
+		Kind:     kind,
+		Platform: c.opts.Platform,
+		ID:       id,
+		Attempts: attempts,
+		Reason:   err.Error(),
+		When:     time.Now(),
+	})
+}
+
+// keep : Returns true if the match passes every filter.
+func (c *Crawler) keep(m *structs.Match) bool {
+	for _, filter := range c.opts.Filters {
+		if !filter(m) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keepSummary : Returns true if the matchlist entry passes every summary filter.
+func (c *Crawler) keepSummary(ms structs.MatchSummary) bool {
+	for _, filter := range c.opts.SummaryFilters {
+		if !filter(ms) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Crawler) matchStored(m *structs.Match) {
+	c.cbLock.Lock()
+	callbacks := c.onMatch
+	c.cbLock.Unlock()
+
+	for _, fn := range callbacks {
+		fn(m)
+	}
+}
+
+func (c *Crawler) requestFailed(err error) {
+	c.cbLock.Lock()
+	callbacks := c.onError
+	c.cbLock.Unlock()
+
+	for _, fn := range callbacks {
+		fn(err)
+	}
+}
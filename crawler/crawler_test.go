@@ -0,0 +1,456 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anyweez/matchgrab/api"
+	"github.com/anyweez/matchgrab/riottest"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// fakeCrawler : Create a crawler that requests from a riottest server as quickly as possible.
+func fakeCrawler(t *testing.T, srv *riottest.Server) (*Crawler, *structs.MatchStore, func()) {
+	return fakeCrawlerWith(t, srv, structs.DedupBloom)
+}
+
+func fakeCrawlerWith(t *testing.T, srv *riottest.Server, dedup string) (*Crawler, *structs.MatchStore, func()) {
+	dir, err := ioutil.TempDir("", "crawler")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := structs.NewMatchStore(dir + "/matches.db")
+	client := api.NewClient("abcde")
+	client.BaseURL = srv.URL
+
+	c := New(Options{
+		Seeds:        []structs.RiotID{structs.RiotID(srv.SeedAccount())},
+		Store:        store,
+		Client:       client,
+		Pacer:        structs.NewPacer(60000, 4),
+		RetryBackoff: 10 * time.Millisecond,
+		Dedup:        dedup,
+	})
+
+	return c, store, func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// collect : Run the crawler until it has stored `count` matches or the timeout passes.
+func collect(t *testing.T, c *Crawler, count int) map[structs.RiotID]bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	seen := make(map[structs.RiotID]bool)
+	var lock sync.Mutex
+
+	c.OnMatch(func(m *structs.Match) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if seen[m.GameID] {
+			t.Errorf("match %d stored twice", m.GameID)
+		}
+		seen[m.GameID] = true
+
+		if len(seen) >= count {
+			c.Stop()
+		}
+	})
+
+	c.Run(ctx)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if len(seen) < count {
+		t.Fatalf("only stored %d of %d matches", len(seen), count)
+	}
+
+	return seen
+}
+
+// TestCrawl : Crawl a fake API and make sure matches end up in the store.
+func TestCrawl(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, store, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	seen := collect(t, c, 20)
+
+	// Writes are asynchronous, so give the store a moment to catch up.
+	for id := range seen {
+		var err error
+		for i := 0; i < 50; i++ {
+			if _, err = store.Get(structs.LegacyPlatform, id); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if err != nil {
+			t.Errorf("match %d wasn't stored: %v", id, err)
+		}
+	}
+}
+
+// TestCrawlWithFaults : Ensure server errors are reported and retried.
+func TestCrawlWithFaults(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50, ServerErrorRate: 0.3})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	errors := 0
+	var lock sync.Mutex
+	c.OnError(func(err error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if _, ok := err.(RequestError); !ok {
+			t.Errorf("unexpected error type: %v", err)
+		}
+		errors++
+	})
+
+	collect(t, c, 20)
+
+	if errors == 0 {
+		t.Error("no errors reported")
+	}
+}
+
+// TestFilters : Ensure rejected matches aren't stored.
+func TestFilters(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	c.opts.Filters = []Filter{
+		func(m *structs.Match) bool { return m.GameID%2 == 0 },
+	}
+
+	for id := range collect(t, c, 10) {
+		if id%2 != 0 {
+			t.Errorf("match %d should have been filtered", id)
+		}
+	}
+}
+
+// TestSelection : Ensure matches outside the selection are never requested or stored.
+func TestSelection(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	selection := Selection{Queues: []int{420}, GameModes: []string{"classic"}}
+	c.opts.Filters = []Filter{selection.Match}
+	c.opts.SummaryFilters = []SummaryFilter{selection.Summary}
+
+	stored := make(chan *structs.Match, 100)
+	c.OnMatch(func(m *structs.Match) {
+		stored <- m
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	c.Run(ctx)
+	close(stored)
+
+	count := 0
+	for m := range stored {
+		if m.QueueID != 420 {
+			t.Errorf("stored match %d from queue %d", m.GameID, m.QueueID)
+		}
+
+		count++
+	}
+
+	if count == 0 {
+		t.Fatal("didn't store any matches")
+	}
+
+	// Everything that was requested should have been stored.
+	requested := 0
+	for _, id := range srv.MatchIDs() {
+		requested += srv.RequestsFor(fmt.Sprintf("/lol/match/v3/matches/%d", id))
+	}
+
+	if requested != count {
+		t.Errorf("requested %d matches but stored %d", requested, count)
+	}
+
+	if selection.Match(&structs.Match{QueueID: 420, GameMode: "ARAM"}) {
+		t.Error("game mode wasn't checked")
+	}
+}
+
+// TestPaging : Ensure summoners with long histories are requested a page at a time and every
+// page is queued.
+func TestPaging(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 10, Matches: 250})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	// Everyone plays in every match, so the seed's matchlist is all 250 of them.
+	if found := collect(t, c, 250); len(found) != 250 {
+		t.Fatalf("found %d matches", len(found))
+	}
+
+	if requests := srv.RequestsFor(fmt.Sprintf("/lol/match/v3/matchlists/by-account/%d", srv.SeedAccount())); requests != 3 {
+		t.Errorf("seed matchlist requested %d times", requests)
+	}
+}
+
+// TestMaxTimeAgo : Ensure old matches are skipped and paging stops at them even if the API
+// ignores beginTime.
+func TestMaxTimeAgo(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 10, Matches: 250, IgnoreBeginTime: true})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	// Matches are 20 minutes apart, so only the newest 50 are recent enough.
+	c.opts.MaxTimeAgo = 990 * time.Minute
+	cutoff := toMillis(time.Now().Add(-c.opts.MaxTimeAgo))
+
+	c.OnMatch(func(m *structs.Match) {
+		if m.GameCreation < cutoff {
+			t.Errorf("match %d is too old", m.GameID)
+		}
+	})
+
+	collect(t, c, 50)
+
+	if requests := srv.RequestsFor(fmt.Sprintf("/lol/match/v3/matchlists/by-account/%d", srv.SeedAccount())); requests != 1 {
+		t.Errorf("seed matchlist requested %d times", requests)
+	}
+}
+
+// TestQueues : Ensure queue restrictions are sent with matchlist requests.
+func TestQueues(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	c.opts.Queues = []int{420}
+	c.OnMatch(func(m *structs.Match) {
+		if m.QueueID != 420 {
+			t.Errorf("match %d is from queue %d", m.GameID, m.QueueID)
+		}
+	})
+
+	collect(t, c, 5)
+}
+
+// TestStop : Ensure Run() returns promptly when the context is cancelled.
+func TestStop(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{Latency: 50 * time.Millisecond})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- c.Run(ctx)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() didn't return after cancel")
+	}
+}
+
+// TestResume : Ensure a new crawler picks up the queues saved by a previous one.
+func TestResume(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, store, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	seen := collect(t, c, 10)
+
+	resumed := New(Options{Store: store})
+	if ok, err := resumed.Resume(); !ok || err != nil {
+		t.Fatalf("couldn't resume: %v", err)
+	}
+
+	if resumed.Progress().KnownSummoners != c.Progress().KnownSummoners {
+		t.Error("summoners weren't restored")
+	}
+
+	for id := range seen {
+		if resumed.matches.Add(id) {
+			t.Errorf("match %d would be requested again", id)
+		}
+	}
+
+	other := New(Options{Platform: "EUW1", Store: store})
+	if ok, _ := other.Resume(); ok {
+		t.Error("resumed a platform that was never crawled")
+	}
+}
+
+// TestExactDedup : Ensure crawls work the same way with exact deduplication.
+func TestExactDedup(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, _, cleanup := fakeCrawlerWith(t, srv, structs.DedupExact)
+	defer cleanup()
+
+	collect(t, c, 20)
+
+	// Run closes the lists on exit; resuming reopens the exact records left on disk.
+	if _, err := c.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.matches.Close()
+	defer c.summoners.Close()
+
+	matches, summoners := c.QueueStats()
+	if matches.Mode != structs.DedupExact || summoners.Seen != 20 {
+		t.Errorf("unexpected stats: %+v %+v", matches, summoners)
+	}
+}
+
+// TestRevisit : Ensure summoners are crawled again after the revisit interval and only pick up
+// matches played since their last crawl.
+func TestRevisit(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 10, Matches: 20})
+	defer srv.Close()
+
+	c, store, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	c.opts.RevisitInterval = 200 * time.Millisecond
+
+	seen := make(map[structs.RiotID]bool)
+	var played []int64
+	var lock sync.Mutex
+
+	// Play a few more matches once everything from the first visit has been stored.
+	c.OnMatch(func(m *structs.Match) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		seen[m.GameID] = true
+
+		if len(seen) == 20 {
+			played = srv.Play(5)
+		} else if len(seen) == 25 {
+			c.Stop()
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range played {
+		if !seen[structs.RiotID(id)] {
+			t.Errorf("didn't pick up %d, played after the first visit", id)
+		}
+	}
+
+	for _, id := range srv.MatchIDs() {
+		if requests := srv.RequestsFor(fmt.Sprintf("/lol/match/v3/matches/%d", id)); requests > 1 {
+			t.Errorf("requested %d %d times", id, requests)
+		}
+	}
+
+	sr, found, _ := store.Summoner(c.Platform(), structs.RiotID(srv.SeedAccount()))
+	if !found || sr.LatestMatch == 0 {
+		t.Errorf("unexpected record for seed: %+v", sr)
+	}
+}
+
+// TestTimelines : Ensure timelines are fetched and stored alongside matches when enabled.
+func TestTimelines(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, store, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	c.opts.Timelines = true
+	seen := collect(t, c, 10)
+
+	// The crawler may stop before the last few timelines are requested.
+	found := 0
+	for id := range seen {
+		tl, err := store.GetTimeline(c.Platform(), id)
+		if err != nil {
+			continue
+		}
+
+		if tl.GameID != id || len(tl.Frames) == 0 {
+			t.Errorf("unexpected timeline for %d: %+v", id, tl)
+		}
+		found++
+	}
+
+	if found < len(seen)-4 {
+		t.Errorf("only stored %d timelines for %d matches", found, len(seen))
+	}
+}
+
+// TestArchive : Ensure raw responses are archived for stored matches when enabled.
+func TestArchive(t *testing.T) {
+	srv := riottest.NewServer(riottest.Options{APIKey: "abcde", Summoners: 20, Matches: 50})
+	defer srv.Close()
+
+	c, store, cleanup := fakeCrawler(t, srv)
+	defer cleanup()
+
+	c.opts.Archive = true
+	seen := collect(t, c, 10)
+
+	for id := range seen {
+		body, err := store.Raw(c.Platform(), id)
+		if err != nil {
+			t.Errorf("match %d wasn't archived: %v", id, err)
+			continue
+		}
+
+		var raw structs.APIMatch
+		if err := json.Unmarshal(body, &raw); err != nil || raw.GameID != id {
+			t.Errorf("archived response for %d doesn't decode", id)
+		}
+	}
+}
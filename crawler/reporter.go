@@ -0,0 +1,51 @@
+package crawler
+
+import "fmt"
+
+// Reporter : Receives updates about what a crawler is doing, i.e. to display them. Reporters
+// are called from multiple goroutines and must be safe for concurrent use.
+type Reporter interface {
+	// Event : A human-readable description of something that happened.
+	Event(msg string)
+	// Request : A request was made to the API.
+	Request(platform string)
+	// Progress : The crawler's queues changed.
+	Progress(p Progress)
+}
+
+// Progress : A snapshot of a crawler's queues.
+type Progress struct {
+	Platform        string
+	QueuedSummoners float32 // fraction of the summoner queue that's filled
+	QueuedMatches   float32 // fraction of the match queue that's filled
+	KnownSummoners  int     // number of distinct summoners seen (approximate)
+}
+
+// nopReporter : Used when no reporter is provided.
+type nopReporter struct{}
+
+func (nopReporter) Event(msg string)        {}
+func (nopReporter) Request(platform string) {}
+func (nopReporter) Progress(p Progress)     {}
+
+// Progress : Returns the current state of the crawler's queues.
+func (c *Crawler) Progress() Progress {
+	return Progress{
+		Platform:        c.opts.Platform,
+		QueuedSummoners: c.summoners.Filled(),
+		QueuedMatches:   c.matches.Filled(),
+		KnownSummoners:  c.summoners.Seen(),
+	}
+}
+
+func (c *Crawler) progress() {
+	c.opts.Reporter.Progress(c.Progress())
+}
+
+func (c *Crawler) event(msg string) {
+	c.opts.Reporter.Event(msg)
+}
+
+func (c *Crawler) eventf(format string, args ...interface{}) {
+	c.opts.Reporter.Event(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,64 @@
+package crawler
+
+import (
+	"strings"
+
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// SummaryFilter : Decides whether a match from a matchlist should be requested. Matches that
+// any summary filter rejects are never requested, which saves a request for each one.
+type SummaryFilter func(ms structs.MatchSummary) bool
+
+// Selection : Describes which matches to crawl. Each field lists the values that are allowed;
+// empty fields allow everything. Matchlists only include the queue and season, so the other
+// fields are only checked once a match has been requested.
+type Selection struct {
+	Queues    []int
+	Seasons   []int
+	Maps      []int
+	GameModes []string // i.e. CLASSIC or ARAM; not case sensitive
+	GameTypes []string // i.e. MATCHED_GAME or CUSTOM_GAME; not case sensitive
+}
+
+// Summary : A SummaryFilter for the parts of the selection that matchlists include.
+func (s Selection) Summary(ms structs.MatchSummary) bool {
+	return allowsInt(s.Queues, ms.Queue) && allowsInt(s.Seasons, ms.Season)
+}
+
+// Match : A Filter for the whole selection.
+func (s Selection) Match(m *structs.Match) bool {
+	return allowsInt(s.Queues, m.QueueID) &&
+		allowsInt(s.Seasons, m.SeasonID) &&
+		allowsInt(s.Maps, m.MapID) &&
+		allowsString(s.GameModes, m.GameMode) &&
+		allowsString(s.GameTypes, m.GameType)
+}
+
+func allowsInt(allowed []int, value int) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allowsString(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, a := range allowed {
+		if strings.EqualFold(a, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// deadLetters : Print all ID's that failed too many times to keep retrying. These can be
+// retried by running `grab crawl -replay`.
+func deadLetters(args []string) {
+	store = structs.NewMatchStore(config.Config.MatchStoreLocation)
+	defer store.Close()
+
+	count := 0
+	err := store.DeadLetters(func(dl structs.DeadLetter) {
+		fmt.Printf("%-8s %-5s %12d  %d attempts, last failed %s: %s\n",
+			dl.Kind, dl.Platform, dl.ID, dl.Attempts, dl.When.Format("2006-01-02 15:04:05"), dl.Reason)
+
+		count++
+	})
+
+	if err != nil {
+		fmt.Println("Error reading dead letters: " + err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("%d dead letters\n", count)
+}
+
+// replayDeadLetters : Move all dead letters for the platforms being crawled back into their
+// retry queues.
+func replayDeadLetters() {
+	store.DeadLetters(func(dl structs.DeadLetter) {
+		c, crawling := crawlers[dl.Platform]
+		if !crawling || !c.Replay(dl) {
+			return
+		}
+
+		store.RemoveDeadLetter(dl)
+		ui.AddEvent(fmt.Sprintf("[ Retry  ] Replaying %s %s %d", dl.Platform, dl.Kind, dl.ID))
+	})
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// fsck : Check every stored match and print the ones that look wrong, i.e. error responses that
+// were stored as matches. Bad records are only reported unless -quarantine or -delete is passed,
+// and unusual participant counts and unknown champions are always only reported.
+func fsck(args []string) {
+	flags := flag.NewFlagSet("fsck", flag.ExitOnError)
+	quarantine := flags.Bool("quarantine", false, "move bad records aside (under quarantine: keys)")
+	remove := flags.Bool("delete", false, "delete bad records")
+	rebuild := flags.Bool("rebuild", false, "rebuild counts and indexes even if no records are removed")
+	champions := flags.Bool("champions", false, "report champion ID's missing from Riot's champion list (requires network access)")
+	patch := flags.String("patch", "", "champion list to check against, i.e. 7.15.1 (default latest)")
+	flags.Parse(args)
+
+	if *quarantine && *remove {
+		fmt.Println("Pass either -quarantine or -delete, not both")
+		os.Exit(1)
+	}
+
+	opts := structs.FsckOptions{
+		Quarantine: *quarantine,
+		Delete:     *remove,
+		Rebuild:    *rebuild,
+	}
+	if *champions {
+		var err error
+		opts.Champions, err = structs.NewRiotChampPack(*patch)
+		if err != nil {
+			fmt.Println("Couldn't load champion list: " + err.Error())
+			os.Exit(1)
+		}
+	}
+
+	// Closed explicitly rather than deferred, since fsck exits with an error status.
+	var err error
+	store, err = structs.OpenMatchStore(config.Config.MatchStoreLocation)
+	if err != nil {
+		fmt.Println("Couldn't open match store: " + err.Error())
+		os.Exit(1)
+	}
+
+	report, err := store.Fsck(opts, func(p structs.FsckProblem) {
+		if p.GameID == 0 && p.Platform == "" {
+			fmt.Printf("%x  %s: %s\n", p.Key, strings.Join(p.Problems, ", "), p.Detail)
+			return
+		}
+
+		fmt.Printf("%-5s %12d  %s: %s\n", p.Platform, p.GameID, strings.Join(p.Problems, ", "), p.Detail)
+	})
+
+	if err != nil {
+		store.Close()
+		fmt.Println("Error checking matches: " + err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Checked %d matches, %d bad (%d only reported)\n", report.Scanned, report.Bad, report.ReportOnly)
+
+	problems := make([]string, 0, len(report.ByProblem))
+	for problem := range report.ByProblem {
+		problems = append(problems, problem)
+	}
+	sort.Strings(problems)
+
+	for _, problem := range problems {
+		fmt.Printf("  %-18s %d\n", problem, report.ByProblem[problem])
+	}
+
+	switch {
+	case *quarantine:
+		fmt.Printf("Quarantined %d records\n", report.Removed)
+	case *remove:
+		fmt.Printf("Deleted %d records\n", report.Removed)
+	}
+
+	if report.Rebuilt {
+		fmt.Printf("Rebuilt counts and indexes (%d matches)\n", store.Count())
+	}
+
+	store.Close()
+
+	// Like fsck(8), fail if problems that could be repaired were left in place.
+	if report.Bad-report.ReportOnly > report.Removed {
+		os.Exit(1)
+	}
+}
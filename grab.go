@@ -1,217 +1,243 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/anyweez/matchgrab/api"
 	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/crawler"
 	"github.com/anyweez/matchgrab/display"
+	"github.com/anyweez/matchgrab/server"
 	"github.com/anyweez/matchgrab/structs"
 )
 
-var matches *structs.IDList
-var summoners *structs.IDList
+// One crawler per platform; each has its own frontier and its own pacer since Riot's rate
+// limits are applied per platform.
+var crawlers map[string]*crawler.Crawler
 var store *structs.MatchStore
 var ui *display.Display
 
-var knownSummoners map[structs.RiotID]bool
-var ksLock sync.Mutex
+// Used by Shutdown() to stop all crawlers and wait for them to finish.
+var stopCrawling context.CancelFunc
+var running sync.WaitGroup
 
-func main() {
-	// Initialize application configuration
-	config.Setup()
+// Requests must ALWAYS be queued earliest first. This order is assumed for rps counting.
+var requestLog chan time.Time
 
-	knownSummoners = make(map[structs.RiotID]bool, 0)
-	matches = structs.NewIDList()
-	summoners = structs.NewIDList()
-	store = structs.NewMatchStore(config.Config.MatchStoreLocation)
-	ui = display.NewDisplay(Shutdown)
+const (
+	// Failed requests are retried up to this many times, waiting twice as long after each
+	// attempt. ID's that fail every attempt are recorded as dead letters.
+	maxAttempts  = 5
+	retryBackoff = 30 * time.Second
+)
 
-	summoners.Add(structs.RiotID(config.Config.SeedAccount))
+func newCrawler(platform string, reporter crawler.Reporter) *crawler.Crawler {
+	selection := crawler.Selection{
+		Queues:    config.Config.Filters.Queues,
+		Seasons:   config.Config.Filters.Seasons,
+		Maps:      config.Config.Filters.Maps,
+		GameModes: config.Config.Filters.GameModes,
+		GameTypes: config.Config.Filters.GameTypes,
+	}
 
-	// Load all existing matches and summoners in parallel
-	store.Each(func(m *structs.Match) {
-		ui.AddEvent(fmt.Sprintf("[ Match  ] Loading %d...", m.GameID))
-		matches.Blacklist(m.GameID) // don't need to re-run matches
+	return crawler.New(crawler.Options{
+		Platform: platform,
+		Seeds:    []structs.RiotID{structs.RiotID(config.Config.SeedFor(platform))},
+		Store:    store,
+		Client:   api.ConfigClient(),
+		Pacer: structs.NewPacer(
+			config.Config.RequestsPerMinute,
+			config.Config.MaxSimultaneousRequests,
+		),
+		Reporter:        reporter,
+		Filters:         []crawler.Filter{selection.Match},
+		SummaryFilters:  []crawler.SummaryFilter{selection.Summary},
+		Queues:          selection.Queues,
+		Seasons:         selection.Seasons,
+		MaxTimeAgo:      config.Config.MaxTimeAgo,
+		RevisitInterval: config.Config.RevisitInterval,
+		MaxAttempts:     maxAttempts,
+		RetryBackoff:    retryBackoff,
+		Dedup:           config.Config.Dedup,
+		Timelines:       config.Config.Timelines,
+		Archive:         config.Config.ArchiveRaw,
+	})
+}
 
-		ksLock.Lock()
-		for _, p := range m.Participants {
-			// Some duplicates (fine), many new folks as well
-			summoners.Add(p.AccountID)
-			knownSummoners[p.AccountID] = true
-		}
-		ksLock.Unlock()
+// Commands that can be passed as the first argument. Running without a command starts a crawl.
+var commands = map[string]func(args []string){
+	"crawl":       crawl,
+	"deadletters": deadLetters,
+	"fsck":        fsck,
+	"migrate":     migrate,
+	"reindex":     reindex,
+	"reprocess":   reprocess,
+	"serve":       serve,
+}
 
-		ui.UpdateQueuedSummoners(summoners.Filled() * 100)
-		ui.UpdateTotalSummoners(len(knownSummoners))
-	})
-	// Shuffle so we don't start with the same group every time.
-	summoners.Shuffle()
+func main() {
+	// Initialize application configuration
+	config.Setup()
 
-	ui.AddEvent("Loaded existing match database!")
+	if len(os.Args) > 1 {
+		cmd, exists := commands[os.Args[1]]
+		if !exists {
+			fmt.Printf("Unknown command: %s\n", os.Args[1])
+			os.Exit(1)
+		}
 
-	// Start requesting and never stop.
-	requestLoop()
+		cmd(os.Args[2:])
+		return
+	}
+
+	crawl([]string{})
 }
 
-func requestLoop() {
-	lastRps := time.Now()
-	rpsInterval := 5 * time.Second // recompute @ this interval
-	rpsWindow := 30                // compute rps using records within this window (seconds)
-	// Start running the requests
-	pace := structs.NewPacer(
-		config.Config.RequestsPerMinute,
-		config.Config.MaxSimultaneousRequests,
-	)
-	// Requests must ALWAYS be queued earliest first. This order is assumed for rps counting.
-	requestLog := make(chan time.Time, 100000)
-
-	// RPS calculation
-	go func() {
-		// Wait until we have a full window before displaying stats.
-		time.Sleep(time.Duration(rpsWindow) * time.Second)
-
-		for {
-			if time.Since(lastRps) > rpsInterval {
-				// Pull all out-of-range records off the queue. Note that we'll pull one
-				// more than we actually intend to, so we'll need to +1 below.
-				for t := range requestLog {
-					if time.Since(t) < time.Duration(rpsWindow)*time.Second {
-						break
-					}
-				}
+// crawl : Download matches until the user quits.
+func crawl(args []string) {
+	flags := flag.NewFlagSet("crawl", flag.ExitOnError)
+	replay := flags.Bool("replay", false, "retry all dead letters before requesting anything new")
+	addr := flags.String("serve", "", "also serve matches over HTTP on this address while crawling")
+	flags.Parse(args)
 
-				rps := float32(len(requestLog)+1) / float32(rpsWindow)
-				ui.UpdateRequestsPerSecond(rps)
+	store = structs.NewMatchStore(config.Config.MatchStoreLocation)
+	ui = display.NewDisplay(Shutdown)
 
-				lastRps = time.Now()
-			}
+	if version := store.Stats().SchemaVersion; version < structs.SchemaVersion {
+		ui.AddEvent(fmt.Sprintf("Store has records from schema version %d; run `grab migrate` to upgrade them", version))
+	}
 
-			time.Sleep(1 * time.Second)
+	go func() {
+		for err := range store.Errors() {
+			ui.AddEvent("[ Store  ] " + err.Error())
 		}
 	}()
 
-	// Seed the RNG
-	rand.Seed(time.Now().Unix())
-	pace.Run(func() {
-		wait := 0
-
-		if rand.Float32() < matches.Filled() { // Request match
-			wait = getMatch()
-			requestLog <- time.Now()
-		} else if summoners.Available() { // Request summoner games
-			wait = getSummoner()
-			requestLog <- time.Now()
-		} else if matches.Available() { // fallback if no summoners are available
-			wait = getMatch()
-			requestLog <- time.Now()
-		}
+	if *addr != "" {
+		go func() {
+			err := http.ListenAndServe(*addr, server.New(server.Options{
+				Store:    store,
+				Platform: config.Config.Platforms[0],
+			}))
+
+			ui.AddEvent("Stopped serving matches: " + err.Error())
+		}()
+	}
+
+	reporter := newDisplayReporter()
+	crawlers = make(map[string]*crawler.Crawler, len(config.Config.Platforms))
+	for _, platform := range config.Config.Platforms {
+		crawlers[platform] = newCrawler(platform, reporter)
+	}
 
-		// If the selected function instructed us to pause, do it.
-		if wait > 0 {
-			pace.PauseFor(time.Duration(wait) * time.Second)
+	// Pick up where the last crawl left off. Platforms that haven't been crawled before are
+	// rebuilt from the matches that are already in the store.
+	rebuild := make(map[string]*crawler.Crawler)
+	for platform, c := range crawlers {
+		resumed, err := c.Resume()
+		if err != nil {
+			ui.AddEvent(fmt.Sprintf("Couldn't resume %s: %s", platform, err.Error()))
 		}
-	}, 0)
-}
 
-// getMatch : Fetches match information for the next match in the queue and adds it to
-// the match store. Returns the number of seconds to wait before making another request
-// (usually zero unless a rate limit was encountered).
-func getMatch() int {
-	match, available := matches.Next()
-	if !available {
-		ui.AddEvent(fmt.Sprintf("[ Match  ] Queue empty, skipping..."))
-		return 0
+		if resumed {
+			ui.AddEvent(fmt.Sprintf("Resumed %s crawl", platform))
+			reporter.Progress(c.Progress())
+		} else {
+			rebuild[platform] = c
+		}
 	}
 
-	ui.AddEvent(fmt.Sprintf("[ Match  ] Fetching %d...", match))
+	if len(rebuild) > 0 {
+		store.Each(func(m *structs.Match) {
+			c, rebuilding := rebuild[m.PlatformID]
+			if !rebuilding {
+				return
+			}
 
-	url := fmt.Sprintf(
-		"https://na1.api.riotgames.com/lol/match/v3/matches/%d",
-		match,
-	)
+			ui.AddEvent(fmt.Sprintf("[ Match  ] Loading %s %d...", m.PlatformID, m.GameID))
+			c.Restore(m) // don't need to re-run matches
+			reporter.Progress(c.Progress())
+		})
+	}
+	// Shuffle so we don't start with the same group every time.
+	for _, c := range crawlers {
+		c.Shuffle()
+	}
 
-	err, wait := api.Get(url, func(body []byte) {
-		var full structs.APIMatch
-		json.Unmarshal(body, &full)
+	ui.AddEvent("Loaded existing match database!")
 
-		// Store the match
-		match := structs.ToMatch(full)
-		store.Add(match)
+	if *replay {
+		replayDeadLetters()
+	}
 
-		// Add all account ID's to the summoner queue.
-		ksLock.Lock()
-		for i := 0; i < len(match.Participants); i++ {
-			summoners.Add(match.Participants[i].AccountID)
-			knownSummoners[match.Participants[i].AccountID] = true
-		}
-		ksLock.Unlock()
+	// Seed the RNG
+	rand.Seed(time.Now().Unix())
 
-		ui.UpdateQueuedSummoners(summoners.Filled() * 100)
-		ui.UpdateTotalSummoners(len(knownSummoners))
-	})
+	// Start requesting and never stop.
+	requestLog = make(chan time.Time, 100000)
+	go rpsLoop()
 
-	if err != nil {
-		ui.AddEvent(err.Error())
-	}
+	var ctx context.Context
+	ctx, stopCrawling = context.WithCancel(context.Background())
 
-	return wait
-}
+	for _, c := range crawlers {
+		running.Add(1)
 
-// Fetch a new set of match ID's for a new summoner. All returned match ID's are queued
-// up for future requests.
-func getSummoner() int {
-	summoner, available := summoners.Next()
-	if !available {
-		ui.AddEvent(fmt.Sprintf("[Summoner] Queue empty, skipping..."))
-		return 0
+		go func(c *crawler.Crawler) {
+			if err := c.Run(ctx); err != nil && err != context.Canceled {
+				ui.AddEvent(fmt.Sprintf("%s crawl stopped: %s", c.Platform(), err.Error()))
+			}
+			running.Done()
+		}(c)
 	}
 
-	ui.AddEvent(fmt.Sprintf("[Summoner] Fetching %d...", summoner))
+	// Shutdown() exits once all crawlers have stopped and saved their queues.
+	select {}
+}
 
-	url := fmt.Sprintf(
-		"https://na1.api.riotgames.com/lol/match/v3/matchlists/by-account/%d",
-		summoner,
-	)
+// rpsLoop : Periodically computes the number of requests per second across all platforms.
+func rpsLoop() {
+	lastRps := time.Now()
+	rpsInterval := 5 * time.Second // recompute @ this interval
+	rpsWindow := 30                // compute rps using records within this window (seconds)
 
-	err, wait := api.Get(url, func(body []byte) {
-		summaries := struct {
-			Matches []structs.MatchSummary `json:"matches"`
-		}{
-			Matches: make([]structs.MatchSummary, 0),
-		}
+	// Wait until we have a full window before displaying stats.
+	time.Sleep(time.Duration(rpsWindow) * time.Second)
 
-		json.Unmarshal(body, &summaries)
+	for {
+		if time.Since(lastRps) > rpsInterval {
+			// Pull all out-of-range records off the queue. Note that we'll pull one
+			// more than we actually intend to, so we'll need to +1 below.
+			for t := range requestLog {
+				if time.Since(t) < time.Duration(rpsWindow)*time.Second {
+					break
+				}
+			}
 
-		// Add all summoners to the
-		for _, match := range summaries.Matches {
-			matchTime := time.Unix(match.Timestamp/1000, 0)
+			rps := float32(len(requestLog)+1) / float32(rpsWindow)
+			ui.UpdateRequestsPerSecond(rps)
 
-			// Only look for matches that occurred recently.
-			if time.Since(matchTime) < config.Config.MaxTimeAgo {
-				matches.Add(match.GameID)
-			}
+			lastRps = time.Now()
 		}
 
-		ui.UpdateQueuedMatches(matches.Filled() * 100)
-		ui.UpdateTotalMatches(store.Count())
-	})
-
-	if err != nil {
-		ui.AddEvent(err.Error())
+		time.Sleep(1 * time.Second)
 	}
-
-	return wait
 }
 
 // Shutdown : Called by termui when the user indicates they want to quit
 func Shutdown() {
 	fmt.Println("Saving remaining match data...")
+	if stopCrawling != nil {
+		stopCrawling()
+		running.Wait()
+	}
 	store.Close()
 
 	fmt.Println("Complete. Exiting...")
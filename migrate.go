@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// migrate : Upgrade records written by older versions of matchgrab to the current schema.
+// Older records can still be read, so this only needs to run once after upgrading.
+func migrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	batch := flags.Int("batch", 1000, "records to rewrite in each batch")
+	flags.Parse(args)
+
+	var err error
+	store, err = structs.OpenMatchStore(config.Config.MatchStoreLocation)
+	if err != nil {
+		fmt.Println("Couldn't open match store: " + err.Error())
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	version := store.Stats().SchemaVersion
+	for _, description := range structs.PendingMigrations(version) {
+		fmt.Println("Migration: " + description)
+	}
+
+	stats, err := store.Migrate(*batch)
+	if err != nil {
+		fmt.Println("Error migrating matches: " + err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Scanned %d matches, migrated %d (%d rekeyed, %d duplicates removed)\n", stats.Scanned, stats.Migrated, stats.Rekeyed, stats.Duplicates)
+
+	if stats.Failed > 0 {
+		fmt.Printf("%d records couldn't be read and were left as-is\n", stats.Failed)
+	} else {
+		fmt.Printf("Store is at schema version %d\n", structs.SchemaVersion)
+	}
+}
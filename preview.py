@@ -29,6 +29,10 @@ class list_acct(object):
         accounts = {}
 
         for mid, raw in db:
+            # Skip everything that isn't a match (indexes, dead letters, etc).
+            if not (len(mid) == 8 or mid[0].isupper()):
+                continue
+
             match = proto.Match()
             match.ParseFromString(raw)
 
@@ -41,32 +45,48 @@ class list_acct(object):
 ## Get all known games for a particular account. Can be used in conjunction with
 ## the list_acct endpoint to get match data for a particular summoner name.
 ##
-## Requires a full iteration of the database.
+## Uses the account index, so only the account's matches are read. Stores created
+## before indexes were kept need to run `grab reindex` first.
 class by_acct(object):
     def GET(self, raw_id):
-        target_id = long(raw_id) # parse from string
+        platform = web.input(platform='NA1').platform
+        prefix = 'idx:account:' + platform + ':' + to_key(raw_id)
 
         db = plyvel.DB('matches/db')
 
         acct = []
 
-        for mid, raw in db:
+        for key in db.iterator(prefix=prefix, include_value=False):
+            game_key = key[-8:]
+            raw = db.get(platform + game_key) or db.get(game_key)
+            if raw is None:
+                continue
+
             match = proto.Match()
             match.ParseFromString(raw)
-
-            for participant in match.Participants:
-                if participant.AccountID == target_id:
-                    acct.append(json.loads(MessageToJson(match)))
+            acct.append(json.loads(MessageToJson(match)))
 
         db.close()
         return json.dumps(acct)
 
-## Get details about a specific match. Very fast lookups.
+## Get details about a specific match. Very fast lookups. Matches are keyed by
+## platform and game ID, so pass `platform` for matches outside of NA1.
 class by_match(object):
     def GET(self, raw_id):
+        platform = web.input(platform='NA1').platform
+
         db = plyvel.DB('matches/db')
 
-        raw_match = db.get(to_key(raw_id))
+        raw_match = db.get(platform + to_key(raw_id))
+
+        # Legacy records are all from NA1 and keyed by game ID alone.
+        if raw_match is None and platform == 'NA1':
+            raw_match = db.get(to_key(raw_id))
+
+        if raw_match is None:
+            db.close()
+            raise web.notfound()
+
         match = proto.Match()
         match.ParseFromString(raw_match)
 
@@ -5,12 +5,21 @@
 Package match is a generated protocol buffer package.
 
 It is generated from these files:
+
 	proto/match.proto
 
 It has these top-level messages:
+
 	Match
+	Team
+	Ban
 	Participant
 	ParticipantStats
+	Perk
+	Timeline
+	Frame
+	ParticipantFrame
+	TimelineEvent
 */
 package match
 
@@ -31,15 +40,20 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 // Maps to struct defined in structs/match.go
 type Match struct {
-	GameID       int64          `protobuf:"varint,1,opt,name=GameID" json:"GameID,omitempty"`
-	SeasonID     int32          `protobuf:"varint,2,opt,name=SeasonID" json:"SeasonID,omitempty"`
-	GameCreation int64          `protobuf:"varint,3,opt,name=GameCreation" json:"GameCreation,omitempty"`
-	GameDuration int32          `protobuf:"varint,4,opt,name=GameDuration" json:"GameDuration,omitempty"`
-	Participants []*Participant `protobuf:"bytes,5,rep,name=Participants" json:"Participants,omitempty"`
-	Bans         []int64        `protobuf:"varint,6,rep,packed,name=Bans" json:"Bans,omitempty"`
-	GameMode     string         `protobuf:"bytes,7,opt,name=GameMode" json:"GameMode,omitempty"`
-	MapID        int32          `protobuf:"varint,8,opt,name=MapID" json:"MapID,omitempty"`
-	GameType     string         `protobuf:"bytes,9,opt,name=GameType" json:"GameType,omitempty"`
+	GameID        int64          `protobuf:"varint,1,opt,name=GameID" json:"GameID,omitempty"`
+	SeasonID      int32          `protobuf:"varint,2,opt,name=SeasonID" json:"SeasonID,omitempty"`
+	GameCreation  int64          `protobuf:"varint,3,opt,name=GameCreation" json:"GameCreation,omitempty"`
+	GameDuration  int32          `protobuf:"varint,4,opt,name=GameDuration" json:"GameDuration,omitempty"`
+	Participants  []*Participant `protobuf:"bytes,5,rep,name=Participants" json:"Participants,omitempty"`
+	Bans          []int64        `protobuf:"varint,6,rep,packed,name=Bans" json:"Bans,omitempty"`
+	GameMode      string         `protobuf:"bytes,7,opt,name=GameMode" json:"GameMode,omitempty"`
+	MapID         int32          `protobuf:"varint,8,opt,name=MapID" json:"MapID,omitempty"`
+	GameType      string         `protobuf:"bytes,9,opt,name=GameType" json:"GameType,omitempty"`
+	PlatformID    string         `protobuf:"bytes,10,opt,name=PlatformID" json:"PlatformID,omitempty"`
+	Teams         []*Team        `protobuf:"bytes,11,rep,name=Teams" json:"Teams,omitempty"`
+	QueueID       int32          `protobuf:"varint,12,opt,name=QueueID" json:"QueueID,omitempty"`
+	GameVersion   string         `protobuf:"bytes,13,opt,name=GameVersion" json:"GameVersion,omitempty"`
+	SchemaVersion int32          `protobuf:"varint,14,opt,name=SchemaVersion" json:"SchemaVersion,omitempty"`
 }
 
 func (m *Match) Reset()                    { *m = Match{} }
@@ -110,21 +124,205 @@ func (m *Match) GetGameType() string {
 	return ""
 }
 
+func (m *Match) GetPlatformID() string {
+	if m != nil {
+		return m.PlatformID
+	}
+	return ""
+}
+
+func (m *Match) GetTeams() []*Team {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+func (m *Match) GetQueueID() int32 {
+	if m != nil {
+		return m.QueueID
+	}
+	return 0
+}
+
+func (m *Match) GetGameVersion() string {
+	if m != nil {
+		return m.GameVersion
+	}
+	return ""
+}
+
+func (m *Match) GetSchemaVersion() int32 {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return 0
+}
+
+type Team struct {
+	TeamID          int32  `protobuf:"varint,1,opt,name=TeamID" json:"TeamID,omitempty"`
+	Winner          bool   `protobuf:"varint,2,opt,name=Winner" json:"Winner,omitempty"`
+	Bans            []*Ban `protobuf:"bytes,3,rep,name=Bans" json:"Bans,omitempty"`
+	FirstBlood      bool   `protobuf:"varint,4,opt,name=FirstBlood" json:"FirstBlood,omitempty"`
+	FirstTower      bool   `protobuf:"varint,5,opt,name=FirstTower" json:"FirstTower,omitempty"`
+	FirstInhibitor  bool   `protobuf:"varint,6,opt,name=FirstInhibitor" json:"FirstInhibitor,omitempty"`
+	FirstBaron      bool   `protobuf:"varint,7,opt,name=FirstBaron" json:"FirstBaron,omitempty"`
+	FirstDragon     bool   `protobuf:"varint,8,opt,name=FirstDragon" json:"FirstDragon,omitempty"`
+	FirstRiftHerald bool   `protobuf:"varint,9,opt,name=FirstRiftHerald" json:"FirstRiftHerald,omitempty"`
+	TowerKills      int32  `protobuf:"varint,10,opt,name=TowerKills" json:"TowerKills,omitempty"`
+	InhibitorKills  int32  `protobuf:"varint,11,opt,name=InhibitorKills" json:"InhibitorKills,omitempty"`
+	BaronKills      int32  `protobuf:"varint,12,opt,name=BaronKills" json:"BaronKills,omitempty"`
+	DragonKills     int32  `protobuf:"varint,13,opt,name=DragonKills" json:"DragonKills,omitempty"`
+	RiftHeraldKills int32  `protobuf:"varint,14,opt,name=RiftHeraldKills" json:"RiftHeraldKills,omitempty"`
+}
+
+func (m *Team) Reset()                    { *m = Team{} }
+func (m *Team) String() string            { return proto.CompactTextString(m) }
+func (*Team) ProtoMessage()               {}
+func (*Team) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Team) GetTeamID() int32 {
+	if m != nil {
+		return m.TeamID
+	}
+	return 0
+}
+
+func (m *Team) GetWinner() bool {
+	if m != nil {
+		return m.Winner
+	}
+	return false
+}
+
+func (m *Team) GetBans() []*Ban {
+	if m != nil {
+		return m.Bans
+	}
+	return nil
+}
+
+func (m *Team) GetFirstBlood() bool {
+	if m != nil {
+		return m.FirstBlood
+	}
+	return false
+}
+
+func (m *Team) GetFirstTower() bool {
+	if m != nil {
+		return m.FirstTower
+	}
+	return false
+}
+
+func (m *Team) GetFirstInhibitor() bool {
+	if m != nil {
+		return m.FirstInhibitor
+	}
+	return false
+}
+
+func (m *Team) GetFirstBaron() bool {
+	if m != nil {
+		return m.FirstBaron
+	}
+	return false
+}
+
+func (m *Team) GetFirstDragon() bool {
+	if m != nil {
+		return m.FirstDragon
+	}
+	return false
+}
+
+func (m *Team) GetFirstRiftHerald() bool {
+	if m != nil {
+		return m.FirstRiftHerald
+	}
+	return false
+}
+
+func (m *Team) GetTowerKills() int32 {
+	if m != nil {
+		return m.TowerKills
+	}
+	return 0
+}
+
+func (m *Team) GetInhibitorKills() int32 {
+	if m != nil {
+		return m.InhibitorKills
+	}
+	return 0
+}
+
+func (m *Team) GetBaronKills() int32 {
+	if m != nil {
+		return m.BaronKills
+	}
+	return 0
+}
+
+func (m *Team) GetDragonKills() int32 {
+	if m != nil {
+		return m.DragonKills
+	}
+	return 0
+}
+
+func (m *Team) GetRiftHeraldKills() int32 {
+	if m != nil {
+		return m.RiftHeraldKills
+	}
+	return 0
+}
+
+type Ban struct {
+	ChampionID int64 `protobuf:"varint,1,opt,name=ChampionID" json:"ChampionID,omitempty"`
+	PickTurn   int32 `protobuf:"varint,2,opt,name=PickTurn" json:"PickTurn,omitempty"`
+}
+
+func (m *Ban) Reset()                    { *m = Ban{} }
+func (m *Ban) String() string            { return proto.CompactTextString(m) }
+func (*Ban) ProtoMessage()               {}
+func (*Ban) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Ban) GetChampionID() int64 {
+	if m != nil {
+		return m.ChampionID
+	}
+	return 0
+}
+
+func (m *Ban) GetPickTurn() int32 {
+	if m != nil {
+		return m.PickTurn
+	}
+	return 0
+}
+
 type Participant struct {
-	SummonerName string            `protobuf:"bytes,1,opt,name=SummonerName" json:"SummonerName,omitempty"`
-	AccountID    int64             `protobuf:"varint,2,opt,name=AccountID" json:"AccountID,omitempty"`
-	ProfileIcon  int32             `protobuf:"varint,3,opt,name=ProfileIcon" json:"ProfileIcon,omitempty"`
-	SummonerID   int64             `protobuf:"varint,4,opt,name=SummonerID" json:"SummonerID,omitempty"`
-	ChampionID   int64             `protobuf:"varint,5,opt,name=ChampionID" json:"ChampionID,omitempty"`
-	TeamID       int32             `protobuf:"varint,6,opt,name=TeamID" json:"TeamID,omitempty"`
-	Winner       bool              `protobuf:"varint,7,opt,name=Winner" json:"Winner,omitempty"`
-	Stats        *ParticipantStats `protobuf:"bytes,8,opt,name=Stats" json:"Stats,omitempty"`
+	SummonerName              string            `protobuf:"bytes,1,opt,name=SummonerName" json:"SummonerName,omitempty"`
+	AccountID                 int64             `protobuf:"varint,2,opt,name=AccountID" json:"AccountID,omitempty"`
+	ProfileIcon               int32             `protobuf:"varint,3,opt,name=ProfileIcon" json:"ProfileIcon,omitempty"`
+	SummonerID                int64             `protobuf:"varint,4,opt,name=SummonerID" json:"SummonerID,omitempty"`
+	ChampionID                int64             `protobuf:"varint,5,opt,name=ChampionID" json:"ChampionID,omitempty"`
+	TeamID                    int32             `protobuf:"varint,6,opt,name=TeamID" json:"TeamID,omitempty"`
+	Winner                    bool              `protobuf:"varint,7,opt,name=Winner" json:"Winner,omitempty"`
+	Stats                     *ParticipantStats `protobuf:"bytes,8,opt,name=Stats" json:"Stats,omitempty"`
+	Lane                      string            `protobuf:"bytes,9,opt,name=Lane" json:"Lane,omitempty"`
+	Role                      string            `protobuf:"bytes,10,opt,name=Role" json:"Role,omitempty"`
+	Spell1                    int32             `protobuf:"varint,11,opt,name=Spell1" json:"Spell1,omitempty"`
+	Spell2                    int32             `protobuf:"varint,12,opt,name=Spell2" json:"Spell2,omitempty"`
+	HighestAchievedSeasonTier string            `protobuf:"bytes,13,opt,name=HighestAchievedSeasonTier" json:"HighestAchievedSeasonTier,omitempty"`
 }
 
 func (m *Participant) Reset()                    { *m = Participant{} }
 func (m *Participant) String() string            { return proto.CompactTextString(m) }
 func (*Participant) ProtoMessage()               {}
-func (*Participant) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*Participant) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *Participant) GetSummonerName() string {
 	if m != nil {
@@ -182,7 +380,43 @@ func (m *Participant) GetStats() *ParticipantStats {
 	return nil
 }
 
+func (m *Participant) GetLane() string {
+	if m != nil {
+		return m.Lane
+	}
+	return ""
+}
+
+func (m *Participant) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *Participant) GetSpell1() int32 {
+	if m != nil {
+		return m.Spell1
+	}
+	return 0
+}
+
+func (m *Participant) GetSpell2() int32 {
+	if m != nil {
+		return m.Spell2
+	}
+	return 0
+}
+
+func (m *Participant) GetHighestAchievedSeasonTier() string {
+	if m != nil {
+		return m.HighestAchievedSeasonTier
+	}
+	return ""
+}
+
 type ParticipantStats struct {
+	// Never written; spells are stored on Participant.
 	Spell1                          int32   `protobuf:"varint,1,opt,name=Spell1" json:"Spell1,omitempty"`
 	Spell2                          int32   `protobuf:"varint,2,opt,name=Spell2" json:"Spell2,omitempty"`
 	Masteries                       []int32 `protobuf:"varint,4,rep,packed,name=masteries" json:"masteries,omitempty"`
@@ -244,12 +478,15 @@ type ParticipantStats struct {
 	ObjectivePlayerScore            int32   `protobuf:"varint,60,opt,name=ObjectivePlayerScore" json:"ObjectivePlayerScore,omitempty"`
 	TotalPlayerScore                int32   `protobuf:"varint,61,opt,name=TotalPlayerScore" json:"TotalPlayerScore,omitempty"`
 	TotalScoreRank                  int32   `protobuf:"varint,62,opt,name=TotalScoreRank" json:"TotalScoreRank,omitempty"`
+	PerkPrimaryStyle                int32   `protobuf:"varint,63,opt,name=PerkPrimaryStyle" json:"PerkPrimaryStyle,omitempty"`
+	PerkSubStyle                    int32   `protobuf:"varint,64,opt,name=PerkSubStyle" json:"PerkSubStyle,omitempty"`
+	Perks                           []*Perk `protobuf:"bytes,65,rep,name=Perks" json:"Perks,omitempty"`
 }
 
 func (m *ParticipantStats) Reset()                    { *m = ParticipantStats{} }
 func (m *ParticipantStats) String() string            { return proto.CompactTextString(m) }
 func (*ParticipantStats) ProtoMessage()               {}
-func (*ParticipantStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*ParticipantStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *ParticipantStats) GetSpell1() int32 {
 	if m != nil {
@@ -678,89 +915,533 @@ func (m *ParticipantStats) GetTotalScoreRank() int32 {
 	return 0
 }
 
+func (m *ParticipantStats) GetPerkPrimaryStyle() int32 {
+	if m != nil {
+		return m.PerkPrimaryStyle
+	}
+	return 0
+}
+
+func (m *ParticipantStats) GetPerkSubStyle() int32 {
+	if m != nil {
+		return m.PerkSubStyle
+	}
+	return 0
+}
+
+func (m *ParticipantStats) GetPerks() []*Perk {
+	if m != nil {
+		return m.Perks
+	}
+	return nil
+}
+
+type Perk struct {
+	ID   int32 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	Var1 int32 `protobuf:"varint,2,opt,name=Var1" json:"Var1,omitempty"`
+	Var2 int32 `protobuf:"varint,3,opt,name=Var2" json:"Var2,omitempty"`
+	Var3 int32 `protobuf:"varint,4,opt,name=Var3" json:"Var3,omitempty"`
+}
+
+func (m *Perk) Reset()                    { *m = Perk{} }
+func (m *Perk) String() string            { return proto.CompactTextString(m) }
+func (*Perk) ProtoMessage()               {}
+func (*Perk) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *Perk) GetID() int32 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *Perk) GetVar1() int32 {
+	if m != nil {
+		return m.Var1
+	}
+	return 0
+}
+
+func (m *Perk) GetVar2() int32 {
+	if m != nil {
+		return m.Var2
+	}
+	return 0
+}
+
+func (m *Perk) GetVar3() int32 {
+	if m != nil {
+		return m.Var3
+	}
+	return 0
+}
+
+// Maps to struct defined in structs/timeline.go
+type Timeline struct {
+	GameID        int64    `protobuf:"varint,1,opt,name=GameID" json:"GameID,omitempty"`
+	PlatformID    string   `protobuf:"bytes,2,opt,name=PlatformID" json:"PlatformID,omitempty"`
+	FrameInterval int64    `protobuf:"varint,3,opt,name=FrameInterval" json:"FrameInterval,omitempty"`
+	Frames        []*Frame `protobuf:"bytes,4,rep,name=Frames" json:"Frames,omitempty"`
+}
+
+func (m *Timeline) Reset()                    { *m = Timeline{} }
+func (m *Timeline) String() string            { return proto.CompactTextString(m) }
+func (*Timeline) ProtoMessage()               {}
+func (*Timeline) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *Timeline) GetGameID() int64 {
+	if m != nil {
+		return m.GameID
+	}
+	return 0
+}
+
+func (m *Timeline) GetPlatformID() string {
+	if m != nil {
+		return m.PlatformID
+	}
+	return ""
+}
+
+func (m *Timeline) GetFrameInterval() int64 {
+	if m != nil {
+		return m.FrameInterval
+	}
+	return 0
+}
+
+func (m *Timeline) GetFrames() []*Frame {
+	if m != nil {
+		return m.Frames
+	}
+	return nil
+}
+
+type Frame struct {
+	Timestamp         int64               `protobuf:"varint,1,opt,name=Timestamp" json:"Timestamp,omitempty"`
+	ParticipantFrames []*ParticipantFrame `protobuf:"bytes,2,rep,name=ParticipantFrames" json:"ParticipantFrames,omitempty"`
+	Events            []*TimelineEvent    `protobuf:"bytes,3,rep,name=Events" json:"Events,omitempty"`
+}
+
+func (m *Frame) Reset()                    { *m = Frame{} }
+func (m *Frame) String() string            { return proto.CompactTextString(m) }
+func (*Frame) ProtoMessage()               {}
+func (*Frame) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Frame) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Frame) GetParticipantFrames() []*ParticipantFrame {
+	if m != nil {
+		return m.ParticipantFrames
+	}
+	return nil
+}
+
+func (m *Frame) GetEvents() []*TimelineEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ParticipantFrame struct {
+	ParticipantID       int32 `protobuf:"varint,1,opt,name=ParticipantID" json:"ParticipantID,omitempty"`
+	X                   int32 `protobuf:"varint,2,opt,name=X" json:"X,omitempty"`
+	Y                   int32 `protobuf:"varint,3,opt,name=Y" json:"Y,omitempty"`
+	CurrentGold         int32 `protobuf:"varint,4,opt,name=CurrentGold" json:"CurrentGold,omitempty"`
+	TotalGold           int32 `protobuf:"varint,5,opt,name=TotalGold" json:"TotalGold,omitempty"`
+	Level               int32 `protobuf:"varint,6,opt,name=Level" json:"Level,omitempty"`
+	XP                  int32 `protobuf:"varint,7,opt,name=XP" json:"XP,omitempty"`
+	MinionsKilled       int32 `protobuf:"varint,8,opt,name=MinionsKilled" json:"MinionsKilled,omitempty"`
+	JungleMinionsKilled int32 `protobuf:"varint,9,opt,name=JungleMinionsKilled" json:"JungleMinionsKilled,omitempty"`
+}
+
+func (m *ParticipantFrame) Reset()                    { *m = ParticipantFrame{} }
+func (m *ParticipantFrame) String() string            { return proto.CompactTextString(m) }
+func (*ParticipantFrame) ProtoMessage()               {}
+func (*ParticipantFrame) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ParticipantFrame) GetParticipantID() int32 {
+	if m != nil {
+		return m.ParticipantID
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetX() int32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetY() int32 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetCurrentGold() int32 {
+	if m != nil {
+		return m.CurrentGold
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetTotalGold() int32 {
+	if m != nil {
+		return m.TotalGold
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetXP() int32 {
+	if m != nil {
+		return m.XP
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetMinionsKilled() int32 {
+	if m != nil {
+		return m.MinionsKilled
+	}
+	return 0
+}
+
+func (m *ParticipantFrame) GetJungleMinionsKilled() int32 {
+	if m != nil {
+		return m.JungleMinionsKilled
+	}
+	return 0
+}
+
+type TimelineEvent struct {
+	Type                    string  `protobuf:"bytes,1,opt,name=Type" json:"Type,omitempty"`
+	Timestamp               int64   `protobuf:"varint,2,opt,name=Timestamp" json:"Timestamp,omitempty"`
+	ParticipantID           int32   `protobuf:"varint,3,opt,name=ParticipantID" json:"ParticipantID,omitempty"`
+	X                       int32   `protobuf:"varint,4,opt,name=X" json:"X,omitempty"`
+	Y                       int32   `protobuf:"varint,5,opt,name=Y" json:"Y,omitempty"`
+	KillerID                int32   `protobuf:"varint,6,opt,name=KillerID" json:"KillerID,omitempty"`
+	VictimID                int32   `protobuf:"varint,7,opt,name=VictimID" json:"VictimID,omitempty"`
+	AssistingParticipantIDs []int32 `protobuf:"varint,8,rep,packed,name=AssistingParticipantIDs" json:"AssistingParticipantIDs,omitempty"`
+	ItemID                  int32   `protobuf:"varint,9,opt,name=ItemID" json:"ItemID,omitempty"`
+	AfterID                 int32   `protobuf:"varint,10,opt,name=AfterID" json:"AfterID,omitempty"`
+	BeforeID                int32   `protobuf:"varint,11,opt,name=BeforeID" json:"BeforeID,omitempty"`
+	SkillSlot               int32   `protobuf:"varint,12,opt,name=SkillSlot" json:"SkillSlot,omitempty"`
+	LevelUpType             string  `protobuf:"bytes,13,opt,name=LevelUpType" json:"LevelUpType,omitempty"`
+	WardType                string  `protobuf:"bytes,14,opt,name=WardType" json:"WardType,omitempty"`
+	CreatorID               int32   `protobuf:"varint,15,opt,name=CreatorID" json:"CreatorID,omitempty"`
+	TeamID                  int32   `protobuf:"varint,16,opt,name=TeamID" json:"TeamID,omitempty"`
+	BuildingType            string  `protobuf:"bytes,17,opt,name=BuildingType" json:"BuildingType,omitempty"`
+	LaneType                string  `protobuf:"bytes,18,opt,name=LaneType" json:"LaneType,omitempty"`
+	TowerType               string  `protobuf:"bytes,19,opt,name=TowerType" json:"TowerType,omitempty"`
+	MonsterType             string  `protobuf:"bytes,20,opt,name=MonsterType" json:"MonsterType,omitempty"`
+	MonsterSubType          string  `protobuf:"bytes,21,opt,name=MonsterSubType" json:"MonsterSubType,omitempty"`
+}
+
+func (m *TimelineEvent) Reset()                    { *m = TimelineEvent{} }
+func (m *TimelineEvent) String() string            { return proto.CompactTextString(m) }
+func (*TimelineEvent) ProtoMessage()               {}
+func (*TimelineEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *TimelineEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetParticipantID() int32 {
+	if m != nil {
+		return m.ParticipantID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetX() int32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetY() int32 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetKillerID() int32 {
+	if m != nil {
+		return m.KillerID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetVictimID() int32 {
+	if m != nil {
+		return m.VictimID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetAssistingParticipantIDs() []int32 {
+	if m != nil {
+		return m.AssistingParticipantIDs
+	}
+	return nil
+}
+
+func (m *TimelineEvent) GetItemID() int32 {
+	if m != nil {
+		return m.ItemID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetAfterID() int32 {
+	if m != nil {
+		return m.AfterID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetBeforeID() int32 {
+	if m != nil {
+		return m.BeforeID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetSkillSlot() int32 {
+	if m != nil {
+		return m.SkillSlot
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetLevelUpType() string {
+	if m != nil {
+		return m.LevelUpType
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetWardType() string {
+	if m != nil {
+		return m.WardType
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetCreatorID() int32 {
+	if m != nil {
+		return m.CreatorID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetTeamID() int32 {
+	if m != nil {
+		return m.TeamID
+	}
+	return 0
+}
+
+func (m *TimelineEvent) GetBuildingType() string {
+	if m != nil {
+		return m.BuildingType
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetLaneType() string {
+	if m != nil {
+		return m.LaneType
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetTowerType() string {
+	if m != nil {
+		return m.TowerType
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetMonsterType() string {
+	if m != nil {
+		return m.MonsterType
+	}
+	return ""
+}
+
+func (m *TimelineEvent) GetMonsterSubType() string {
+	if m != nil {
+		return m.MonsterSubType
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Match)(nil), "Match")
+	proto.RegisterType((*Team)(nil), "Team")
+	proto.RegisterType((*Ban)(nil), "Ban")
 	proto.RegisterType((*Participant)(nil), "Participant")
 	proto.RegisterType((*ParticipantStats)(nil), "ParticipantStats")
+	proto.RegisterType((*Perk)(nil), "Perk")
+	proto.RegisterType((*Timeline)(nil), "Timeline")
+	proto.RegisterType((*Frame)(nil), "Frame")
+	proto.RegisterType((*ParticipantFrame)(nil), "ParticipantFrame")
+	proto.RegisterType((*TimelineEvent)(nil), "TimelineEvent")
 }
 
 func init() { proto.RegisterFile("proto/match.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1194 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x97, 0xed, 0x76, 0xd3, 0x46,
-	0x13, 0xc7, 0x4f, 0x30, 0x0e, 0x64, 0x13, 0x02, 0x59, 0x02, 0xec, 0x03, 0x3c, 0xc1, 0x4d, 0x69,
-	0x70, 0x29, 0x0d, 0x10, 0x28, 0xa5, 0x6f, 0x9c, 0x82, 0xcd, 0x8b, 0xdb, 0x04, 0x52, 0xd9, 0x94,
-	0xcf, 0x1b, 0x7b, 0xb0, 0xb7, 0x48, 0xbb, 0x3e, 0xab, 0x15, 0x9c, 0xdc, 0x42, 0x6f, 0xa0, 0xf7,
-	0xd9, 0x2b, 0xe8, 0x99, 0x59, 0x45, 0x5e, 0xc9, 0x4a, 0xf8, 0x96, 0xf9, 0xcd, 0x7f, 0x46, 0xde,
-	0x79, 0x59, 0x29, 0x6c, 0x6d, 0x6a, 0x8d, 0x33, 0x77, 0x13, 0xe9, 0x86, 0x93, 0x6d, 0xfa, 0x7b,
-	0xf3, 0x9f, 0x53, 0xac, 0xb9, 0x87, 0x36, 0xbf, 0xcc, 0x16, 0x5f, 0xca, 0x04, 0x7a, 0x5d, 0xb1,
-	0xd0, 0x5a, 0x68, 0x37, 0xa2, 0xdc, 0xe2, 0x57, 0xd9, 0xd9, 0x3e, 0xc8, 0xd4, 0xe8, 0x5e, 0x57,
-	0x9c, 0x6a, 0x2d, 0xb4, 0x9b, 0x51, 0x61, 0xf3, 0x4d, 0xb6, 0x82, 0xaa, 0x8e, 0x05, 0xe9, 0x94,
-	0xd1, 0xa2, 0x41, 0x91, 0x25, 0x76, 0xa4, 0xe9, 0x66, 0xd6, 0x6b, 0x4e, 0x53, 0x8e, 0x12, 0xe3,
-	0xf7, 0xd8, 0xca, 0xbe, 0xb4, 0x4e, 0x0d, 0xd5, 0x54, 0x6a, 0x97, 0x8a, 0x66, 0xab, 0xd1, 0x5e,
-	0xde, 0x59, 0xd9, 0x0e, 0x60, 0x54, 0x52, 0x70, 0xce, 0x4e, 0x3f, 0x93, 0x3a, 0x15, 0x8b, 0xad,
-	0x46, 0xbb, 0x11, 0xd1, 0xdf, 0xf8, 0x4b, 0x31, 0xeb, 0x9e, 0x19, 0x81, 0x38, 0xd3, 0x5a, 0x68,
-	0x2f, 0x45, 0x85, 0xcd, 0xd7, 0xf1, 0x98, 0xd3, 0x5e, 0x57, 0x9c, 0xa5, 0xc7, 0x7b, 0xe3, 0x28,
-	0x62, 0x70, 0x38, 0x05, 0xb1, 0x34, 0x8b, 0x40, 0x7b, 0xf3, 0xef, 0x53, 0x6c, 0x39, 0x78, 0x24,
-	0x9e, 0xa3, 0x9f, 0x25, 0x89, 0xd1, 0x60, 0x5f, 0xcb, 0x04, 0xa8, 0x4a, 0x4b, 0x51, 0x89, 0xf1,
-	0xeb, 0x6c, 0xe9, 0xe9, 0x70, 0x68, 0x32, 0xed, 0xf2, 0x62, 0x35, 0xa2, 0x19, 0xe0, 0x2d, 0xb6,
-	0xbc, 0x6f, 0xcd, 0x7b, 0x15, 0x43, 0x6f, 0x98, 0x17, 0xab, 0x19, 0x85, 0x88, 0x6f, 0x30, 0x76,
-	0x94, 0xaf, 0xd7, 0xa5, 0x4a, 0x35, 0xa2, 0x80, 0xa0, 0xbf, 0x33, 0x91, 0xc9, 0x54, 0x51, 0x37,
-	0x9a, 0xde, 0x3f, 0x23, 0xd8, 0xc3, 0x01, 0xc8, 0xa4, 0xd7, 0x15, 0x8b, 0x94, 0x3c, 0xb7, 0x90,
-	0xbf, 0x53, 0x5a, 0x83, 0xa5, 0xba, 0x9c, 0x8d, 0x72, 0x8b, 0xdf, 0x62, 0xcd, 0xbe, 0x93, 0x2e,
-	0xa5, 0xaa, 0x2c, 0xef, 0xac, 0x85, 0x05, 0x27, 0x47, 0xe4, 0xfd, 0x9b, 0xff, 0xae, 0xb3, 0x0b,
-	0x55, 0x1f, 0x66, 0xed, 0x4f, 0x21, 0x8e, 0xef, 0x53, 0x2d, 0x9a, 0x51, 0x6e, 0x15, 0x7c, 0x27,
-	0x9f, 0x97, 0xdc, 0xc2, 0xea, 0x24, 0x32, 0x75, 0x60, 0x15, 0xa4, 0xe2, 0x74, 0xab, 0xd1, 0x6e,
-	0x46, 0x33, 0x80, 0x1d, 0x8a, 0x32, 0x0d, 0xbe, 0xf9, 0xcd, 0xc8, 0x1b, 0x48, 0x7b, 0x0e, 0x12,
-	0xdf, 0xe8, 0x66, 0xe4, 0x0d, 0xa4, 0xbf, 0xab, 0x38, 0x4e, 0xe9, 0x38, 0xcd, 0xc8, 0x1b, 0xf8,
-	0xdc, 0x2e, 0x48, 0x37, 0x49, 0xf3, 0x26, 0xe7, 0x16, 0x17, 0xec, 0xcc, 0xd3, 0x34, 0x55, 0xa9,
-	0x4b, 0xa9, 0xc9, 0xcd, 0xe8, 0xc8, 0xe4, 0xf7, 0xd8, 0xc5, 0x5d, 0x69, 0xc7, 0x90, 0x3a, 0xcc,
-	0xa0, 0xf4, 0xb8, 0x3f, 0xb5, 0x00, 0x82, 0x91, 0xaa, 0xce, 0xc5, 0x6f, 0xb3, 0x0b, 0x39, 0xde,
-	0xcb, 0x62, 0xa7, 0xd0, 0x27, 0x96, 0x49, 0x3e, 0xc7, 0xf9, 0x4d, 0x76, 0x2e, 0x8c, 0x4d, 0xc5,
-	0x0a, 0x09, 0xcb, 0x90, 0x3f, 0x62, 0x97, 0x77, 0x8d, 0xc6, 0xc8, 0x81, 0x4a, 0xa0, 0x3f, 0x05,
-	0xed, 0x76, 0xd5, 0x47, 0xa5, 0xc7, 0xe2, 0x1c, 0xc9, 0x8f, 0xf1, 0xe2, 0x34, 0x75, 0x4d, 0x76,
-	0x10, 0x83, 0xaf, 0xc4, 0xaa, 0x9f, 0xa6, 0x00, 0xa1, 0x62, 0x60, 0xd5, 0xf4, 0x48, 0x71, 0xde,
-	0x2b, 0x02, 0x84, 0x8a, 0x3f, 0x32, 0x39, 0xb2, 0xd2, 0x2b, 0x2e, 0x78, 0x45, 0x80, 0x70, 0xe2,
-	0xf6, 0x41, 0xbb, 0x5c, 0xb0, 0x46, 0x82, 0x80, 0x60, 0x86, 0xb7, 0xda, 0x82, 0x8c, 0xbd, 0x80,
-	0xfb, 0x0c, 0x01, 0xc2, 0x8a, 0x0d, 0x8c, 0x93, 0x71, 0x57, 0x26, 0x72, 0x0c, 0x5d, 0x90, 0xb1,
-	0x13, 0x17, 0x7d, 0xc5, 0xaa, 0x1c, 0xb5, 0x7b, 0x72, 0xac, 0x86, 0xa1, 0x76, 0xdd, 0x6b, 0xab,
-	0x1c, 0x7b, 0xb7, 0x3f, 0x39, 0x4c, 0xd5, 0xb0, 0x9c, 0xfa, 0x92, 0xef, 0x5d, 0x8d, 0x8b, 0xb7,
-	0xd9, 0xf9, 0x81, 0xcd, 0x20, 0x54, 0x5f, 0x26, 0x75, 0x15, 0xf3, 0x87, 0xec, 0x52, 0xde, 0xcd,
-	0x8e, 0x55, 0x0e, 0xf3, 0xf4, 0x9d, 0x55, 0x1f, 0x40, 0x5c, 0x21, 0x7d, 0xbd, 0x93, 0xff, 0xca,
-	0xae, 0x55, 0x4f, 0x34, 0x30, 0x47, 0xdb, 0x99, 0x0a, 0x41, 0xb1, 0x27, 0x49, 0x30, 0x43, 0xf5,
-	0x9c, 0x61, 0x86, 0xff, 0xf9, 0x0c, 0x27, 0x48, 0xf8, 0x0b, 0xb6, 0x51, 0x73, 0xf4, 0x30, 0xc9,
-	0x55, 0x4a, 0xf2, 0x19, 0x15, 0x7f, 0xc2, 0xae, 0x56, 0x8a, 0x12, 0xe6, 0xb8, 0x46, 0x39, 0x4e,
-	0x50, 0xe0, 0xae, 0xd3, 0x41, 0x5f, 0x81, 0x8c, 0xc5, 0x75, 0x92, 0xcf, 0x40, 0x31, 0x13, 0x6f,
-	0xb5, 0x72, 0x29, 0x12, 0x18, 0x89, 0xff, 0x07, 0x33, 0x11, 0x70, 0xec, 0xb3, 0x7f, 0x46, 0x1f,
-	0xe2, 0xf7, 0x7b, 0xca, 0xa9, 0xb1, 0x74, 0x30, 0x12, 0x1b, 0xbe, 0xcf, 0x35, 0x2e, 0xfe, 0x98,
-	0x5d, 0x29, 0xfd, 0xaa, 0x37, 0x07, 0x7f, 0xc1, 0xd0, 0xa9, 0x8f, 0x90, 0x8a, 0x1b, 0x14, 0x75,
-	0x9c, 0x9b, 0xef, 0xb0, 0xf5, 0x92, 0x6b, 0x90, 0x59, 0x0b, 0x2e, 0x15, 0x2d, 0x0a, 0xab, 0xf5,
-	0xe1, 0x06, 0xfc, 0xa9, 0x52, 0x65, 0x74, 0x7f, 0x68, 0x2c, 0x88, 0x2f, 0xfc, 0x06, 0x04, 0x88,
-	0xe6, 0x4e, 0x25, 0xd0, 0xe9, 0x28, 0x3d, 0x7e, 0xe3, 0x26, 0x60, 0x53, 0xb1, 0x99, 0xcf, 0x5d,
-	0x19, 0x57, 0x76, 0x65, 0x20, 0x3f, 0x80, 0x16, 0x5f, 0xce, 0xed, 0x0a, 0x71, 0xbe, 0xcd, 0x38,
-	0x0d, 0x42, 0x59, 0x7d, 0x93, 0xd4, 0x35, 0x9e, 0xf9, 0x7d, 0xf1, 0x01, 0x5f, 0xd5, 0xed, 0x8b,
-	0x8f, 0x28, 0xed, 0x8b, 0x57, 0x6f, 0x55, 0xf7, 0xc5, 0x2b, 0x37, 0x18, 0x7b, 0x69, 0xe2, 0xd1,
-	0x73, 0x69, 0x35, 0x8c, 0xc4, 0x2d, 0x7f, 0x4b, 0xcc, 0x08, 0x4e, 0x03, 0x5a, 0x74, 0x7d, 0x89,
-	0xb6, 0x9f, 0x86, 0x02, 0xd0, 0x3d, 0x45, 0xc5, 0xf4, 0x77, 0xc8, 0xd7, 0xf9, 0x3d, 0x35, 0x43,
-	0x7c, 0x8b, 0xad, 0xf6, 0xf4, 0x44, 0x1d, 0x28, 0x67, 0xac, 0x17, 0xdd, 0x26, 0x51, 0x85, 0x62,
-	0x4d, 0xa8, 0x4e, 0x7b, 0x4a, 0xe3, 0x14, 0x22, 0x84, 0x91, 0xf8, 0xc6, 0xd7, 0x64, 0xde, 0x83,
-	0xfd, 0x7e, 0x0d, 0x99, 0xb3, 0xd5, 0x88, 0x3b, 0xbe, 0xdf, 0x75, 0x3e, 0xdc, 0xb0, 0x3a, 0x8e,
-	0x6f, 0xda, 0xdf, 0x32, 0x3d, 0x8e, 0x41, 0x7c, 0xeb, 0x37, 0xec, 0x64, 0x15, 0x7f, 0xc5, 0x6e,
-	0xd4, 0x29, 0x9e, 0x6b, 0x48, 0x0e, 0xf3, 0x44, 0xdb, 0x94, 0xe8, 0x73, 0x32, 0xda, 0x55, 0x3c,
-	0x1b, 0x4d, 0x93, 0x35, 0x9f, 0x46, 0x1d, 0xa3, 0x9d, 0x35, 0xb1, 0xbf, 0xe2, 0xee, 0xe6, 0xbb,
-	0x7a, 0xac, 0xa2, 0xf8, 0xaa, 0xd8, 0x85, 0x8f, 0x10, 0x8b, 0x7b, 0xbe, 0x7b, 0x33, 0x82, 0xfb,
-	0xe4, 0xc7, 0xf9, 0x9d, 0xb4, 0xa3, 0xf4, 0x99, 0xc9, 0xc6, 0x13, 0xd7, 0xd3, 0xf8, 0xa1, 0x24,
-	0xee, 0xfb, 0x7d, 0x3a, 0xc6, 0x8d, 0xef, 0xb6, 0xbe, 0x1a, 0x4f, 0xdc, 0x7c, 0xe0, 0x8e, 0x7f,
-	0xb7, 0xd5, 0x7b, 0x71, 0x22, 0x08, 0xee, 0xc7, 0x72, 0x08, 0x23, 0xf1, 0xc0, 0x4f, 0x44, 0x80,
-	0x0a, 0x45, 0xde, 0xb0, 0x87, 0x81, 0x22, 0xef, 0xd3, 0x16, 0x5b, 0x7d, 0xa1, 0x6c, 0xea, 0x9e,
-	0xc5, 0xc6, 0x8c, 0xe8, 0x3d, 0xfd, 0x1d, 0x7d, 0xfb, 0x54, 0x28, 0xee, 0xdc, 0x8c, 0xf8, 0x0f,
-	0x03, 0xf1, 0x88, 0x94, 0x73, 0xbc, 0xc8, 0x39, 0x30, 0x9f, 0x80, 0x46, 0x4e, 0x7c, 0x1f, 0xe4,
-	0x2c, 0x68, 0x91, 0x93, 0x48, 0x9e, 0xf3, 0x71, 0x90, 0x33, 0xe0, 0x38, 0xb3, 0xc4, 0x4a, 0xa3,
-	0x2c, 0x7e, 0x20, 0x75, 0x8d, 0x07, 0x67, 0xb6, 0x4c, 0xf3, 0xfc, 0x3f, 0x52, 0x44, 0xad, 0x8f,
-	0xdf, 0x61, 0x6b, 0x1d, 0x93, 0x1c, 0x48, 0xb7, 0x1f, 0xcb, 0x43, 0xb0, 0xfe, 0xa6, 0xfa, 0x89,
-	0x6a, 0x36, 0xef, 0xc0, 0x27, 0x14, 0x77, 0x62, 0x18, 0xf0, 0xb3, 0xdf, 0x8a, 0x3a, 0x5f, 0x71,
-	0x73, 0x85, 0xfa, 0x5f, 0x82, 0x9b, 0x2b, 0xd4, 0x6e, 0xb1, 0x55, 0x62, 0x64, 0x45, 0x52, 0x7f,
-	0x10, 0x4f, 0xfc, 0x36, 0x97, 0xe9, 0xc1, 0x22, 0xfd, 0x8b, 0xf2, 0xe0, 0xbf, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0xe1, 0x60, 0xda, 0xd9, 0xb7, 0x0c, 0x00, 0x00,
+	// 1945 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x85, 0x58, 0xd9, 0x76, 0x1b, 0x45,
+	0x10, 0x3d, 0xb6, 0x2c, 0x2f, 0x6d, 0xc7, 0xc4, 0x9d, 0x85, 0x21, 0x81, 0x10, 0x44, 0x48, 0xc2,
+	0x66, 0x88, 0xc3, 0xbe, 0x7b, 0xc9, 0x06, 0x76, 0x62, 0x46, 0x4a, 0x08, 0x8f, 0x63, 0xa9, 0x2d,
+	0x35, 0x1e, 0xcd, 0xe8, 0xf4, 0xb4, 0xcc, 0xf1, 0x1f, 0xf0, 0xc4, 0x03, 0x5f, 0xc0, 0x57, 0xf0,
+	0x2d, 0xbc, 0xf1, 0x25, 0x9c, 0x43, 0x2d, 0xad, 0x99, 0x9e, 0xd1, 0x28, 0x3c, 0x49, 0x75, 0xab,
+	0xba, 0xba, 0xba, 0xb6, 0xee, 0x1a, 0xb1, 0x31, 0x32, 0xa9, 0x4d, 0x3f, 0x18, 0x46, 0xb6, 0x3b,
+	0xd8, 0xa4, 0xff, 0xad, 0xbf, 0x1a, 0xa2, 0x79, 0x80, 0xb4, 0xbc, 0x2c, 0x16, 0x1f, 0x44, 0x43,
+	0xf5, 0x68, 0x2f, 0x98, 0xbb, 0x3e, 0x77, 0xbb, 0x11, 0x3a, 0x4a, 0x5e, 0x11, 0xcb, 0x6d, 0x15,
+	0x65, 0x69, 0x02, 0x9c, 0x79, 0xe0, 0x34, 0xc3, 0x9c, 0x96, 0x2d, 0xb1, 0x86, 0x52, 0xbb, 0x46,
+	0x45, 0x56, 0xa7, 0x49, 0xd0, 0xa0, 0x95, 0x25, 0x6c, 0x22, 0xb3, 0x37, 0x36, 0x2c, 0xb3, 0x40,
+	0x3a, 0x4a, 0x98, 0xfc, 0x50, 0xac, 0x1d, 0x46, 0xc6, 0xea, 0xae, 0x1e, 0x45, 0x89, 0xcd, 0x82,
+	0xe6, 0xf5, 0xc6, 0xed, 0xd5, 0xad, 0xb5, 0x4d, 0x0f, 0x0c, 0x4b, 0x12, 0x52, 0x8a, 0x85, 0x9d,
+	0x28, 0xc9, 0x82, 0x45, 0x90, 0x6c, 0x84, 0xf4, 0x1f, 0x2d, 0x45, 0xad, 0x07, 0x69, 0x4f, 0x05,
+	0x4b, 0xb0, 0xcb, 0x4a, 0x98, 0xd3, 0xf2, 0x22, 0x1e, 0x73, 0x04, 0x47, 0x58, 0xa6, 0xed, 0x99,
+	0x98, 0xac, 0xe8, 0x9c, 0x8d, 0x54, 0xb0, 0x52, 0xac, 0x40, 0x5a, 0x5e, 0x13, 0xe2, 0x30, 0x8e,
+	0xec, 0x71, 0x6a, 0x86, 0xb0, 0x4c, 0x10, 0xd7, 0x43, 0xe4, 0x55, 0xd1, 0xec, 0xa8, 0x68, 0x98,
+	0x05, 0xab, 0x64, 0x6c, 0x73, 0x13, 0xa9, 0x90, 0x31, 0x19, 0x88, 0xa5, 0x1f, 0xc7, 0x6a, 0x8c,
+	0xde, 0x5c, 0xa3, 0x0d, 0x27, 0xa4, 0xbc, 0x2e, 0x56, 0x71, 0x8b, 0x67, 0xca, 0x64, 0xe8, 0x8d,
+	0x73, 0xa4, 0xd7, 0x87, 0xe4, 0x0d, 0x71, 0xae, 0xdd, 0x1d, 0xa8, 0x61, 0x34, 0x91, 0x59, 0x27,
+	0x0d, 0x65, 0xb0, 0xf5, 0x77, 0x43, 0x2c, 0xe0, 0x5e, 0x18, 0x37, 0xfc, 0x75, 0x71, 0x6b, 0x86,
+	0x8e, 0x42, 0xfc, 0x27, 0x9d, 0x24, 0xca, 0x50, 0xd4, 0x96, 0x43, 0x47, 0x81, 0x69, 0xec, 0xb9,
+	0x06, 0x99, 0xbd, 0xb0, 0x09, 0x84, 0xf3, 0x1f, 0x9c, 0xf8, 0xbe, 0x36, 0x99, 0xdd, 0x89, 0xd3,
+	0xb4, 0x47, 0x71, 0x5a, 0x0e, 0x3d, 0x24, 0xe7, 0x77, 0xd2, 0x5f, 0x41, 0x6b, 0xd3, 0xe3, 0x13,
+	0x22, 0x6f, 0x8a, 0x75, 0xa2, 0x1e, 0x25, 0x03, 0x7d, 0xa4, 0x6d, 0x6a, 0x20, 0x3a, 0x28, 0x53,
+	0x41, 0x8b, 0x7d, 0x22, 0x03, 0xa7, 0x5b, 0xf2, 0xf7, 0x41, 0x04, 0x5d, 0x44, 0xd4, 0x9e, 0x89,
+	0xfa, 0x20, 0xb0, 0x4c, 0x02, 0x3e, 0x24, 0x6f, 0x8b, 0x97, 0x88, 0x0c, 0xf5, 0xb1, 0x7d, 0xa8,
+	0x4c, 0x14, 0xf7, 0x28, 0x7c, 0xcb, 0x61, 0x15, 0xc6, 0xbd, 0xc8, 0xb8, 0x1f, 0x74, 0x1c, 0x67,
+	0x14, 0xc5, 0x66, 0xe8, 0x21, 0x68, 0x73, 0x6e, 0x18, 0xcb, 0xac, 0x92, 0x4c, 0x05, 0x45, 0x3d,
+	0x64, 0x1c, 0xcb, 0x70, 0x4c, 0x3d, 0x04, 0x6d, 0x66, 0xdb, 0x58, 0xe0, 0x1c, 0x09, 0xf8, 0x10,
+	0xda, 0x5c, 0xd8, 0xc5, 0x52, 0x1c, 0xd8, 0x2a, 0xdc, 0xda, 0x16, 0x0d, 0x88, 0x07, 0x6e, 0xb9,
+	0x3b, 0x88, 0x86, 0x23, 0x4d, 0xa5, 0xc7, 0x45, 0xe9, 0x21, 0x98, 0xbc, 0x87, 0xba, 0x7b, 0xd2,
+	0x19, 0x9b, 0x64, 0x52, 0x98, 0x13, 0xba, 0xf5, 0x67, 0x43, 0xac, 0x7a, 0xf5, 0x82, 0x45, 0xd8,
+	0x1e, 0x0f, 0x87, 0x29, 0x24, 0xc0, 0x63, 0x48, 0x35, 0xd2, 0xb6, 0x12, 0x96, 0x30, 0xf9, 0xaa,
+	0x58, 0xd9, 0xee, 0x76, 0xd3, 0x71, 0x62, 0x5d, 0xa5, 0x37, 0xc2, 0x02, 0xc0, 0x03, 0x1e, 0x9a,
+	0xf4, 0x58, 0xc7, 0xea, 0x51, 0xd7, 0x55, 0x3a, 0x1c, 0xd0, 0x83, 0xd0, 0xde, 0x89, 0x3e, 0x50,
+	0xb0, 0xc0, 0xf6, 0x16, 0x48, 0xe5, 0x3c, 0xcd, 0xa9, 0xf3, 0x14, 0x89, 0xbc, 0x38, 0x23, 0x91,
+	0x97, 0x4a, 0x89, 0x7c, 0x4b, 0x34, 0xdb, 0x36, 0x82, 0x6e, 0x81, 0x09, 0xb2, 0xba, 0xb5, 0xe1,
+	0x77, 0x0b, 0x62, 0x84, 0xcc, 0xc7, 0x5e, 0xb1, 0x1f, 0x25, 0x93, 0x0a, 0xa7, 0xff, 0x88, 0x85,
+	0x69, 0xac, 0x5c, 0x5d, 0xd3, 0x7f, 0xdc, 0xa8, 0x3d, 0x52, 0x71, 0x7c, 0xc7, 0xe5, 0x80, 0xa3,
+	0x72, 0x7c, 0xcb, 0xc5, 0xdd, 0x51, 0xf2, 0x2b, 0xf1, 0xca, 0x43, 0xdd, 0x1f, 0xa8, 0xcc, 0x6e,
+	0x77, 0x07, 0x5a, 0x9d, 0xaa, 0x1e, 0x37, 0xc6, 0x8e, 0x06, 0x5b, 0xb9, 0xb0, 0x67, 0x0b, 0xb4,
+	0xfe, 0xb9, 0x24, 0xce, 0x57, 0x2d, 0xf6, 0x4c, 0x98, 0x9b, 0x61, 0xc2, 0x7c, 0xc9, 0x04, 0x88,
+	0xd9, 0x30, 0xca, 0xac, 0x32, 0x5a, 0x65, 0xe0, 0xf2, 0x06, 0xb0, 0x0a, 0x00, 0x9b, 0x5e, 0x38,
+	0x4e, 0x14, 0xf7, 0x53, 0x68, 0x7a, 0x44, 0x20, 0xfa, 0xc8, 0xaa, 0x21, 0xf7, 0x4e, 0x40, 0x89,
+	0x40, 0x94, 0x93, 0x72, 0x89, 0x1b, 0x24, 0x27, 0x2d, 0xec, 0xbb, 0x07, 0x7d, 0x7c, 0x90, 0xb9,
+	0xbe, 0xe9, 0x28, 0xec, 0x6f, 0xdb, 0x59, 0xa6, 0x33, 0xf0, 0xfe, 0x0a, 0xf7, 0x37, 0x47, 0x42,
+	0x2b, 0xbf, 0xb0, 0x1f, 0x99, 0x3e, 0x9c, 0x19, 0x35, 0xe8, 0xa4, 0xdf, 0x1e, 0x19, 0xa5, 0x5c,
+	0xe5, 0xd5, 0xb1, 0xe4, 0x3b, 0xe2, 0xbc, 0x83, 0x0f, 0xc6, 0xb1, 0xd5, 0xc8, 0x73, 0x01, 0x98,
+	0xc2, 0xb1, 0x37, 0xfa, 0x6b, 0x27, 0x95, 0x58, 0x06, 0xe5, 0x27, 0xe2, 0xf2, 0x7e, 0x9a, 0xe0,
+	0xca, 0x8e, 0x1e, 0x2a, 0x70, 0x55, 0x62, 0xf7, 0xf5, 0x29, 0xf0, 0x5d, 0x5d, 0xce, 0xe0, 0x52,
+	0x11, 0xa7, 0xe3, 0xa3, 0x58, 0xf9, 0xe5, 0xe9, 0x43, 0x28, 0xd1, 0x31, 0x7a, 0x34, 0x91, 0x78,
+	0x89, 0x25, 0x3c, 0x08, 0x25, 0x7e, 0x1c, 0x47, 0x3d, 0x13, 0xb1, 0xc4, 0x79, 0x96, 0xf0, 0x20,
+	0xba, 0x58, 0x60, 0x4f, 0x27, 0xb0, 0xc1, 0xad, 0xa4, 0x40, 0x50, 0xc3, 0xd3, 0x04, 0x6e, 0xcf,
+	0x98, 0x05, 0x24, 0x6b, 0xf0, 0x20, 0xf4, 0x58, 0x27, 0xb5, 0x51, 0xbc, 0x17, 0x0d, 0xa3, 0xbe,
+	0x82, 0x90, 0xc4, 0x36, 0xb8, 0xc0, 0x1e, 0xab, 0xe2, 0x28, 0x7b, 0x10, 0xf5, 0x75, 0xd7, 0x97,
+	0xbd, 0xc8, 0xb2, 0x55, 0x1c, 0x63, 0x77, 0x38, 0x38, 0xcb, 0x74, 0xb7, 0xac, 0xfa, 0x12, 0xc7,
+	0xae, 0x86, 0x85, 0x4d, 0xad, 0x63, 0xc6, 0xca, 0x97, 0xbe, 0xcc, 0x4d, 0xad, 0x02, 0xcb, 0x8f,
+	0xc4, 0x25, 0x17, 0xcd, 0x5d, 0xa3, 0x2d, 0xea, 0x69, 0x5b, 0xa3, 0x4f, 0x54, 0xf0, 0x32, 0xc9,
+	0xd7, 0x33, 0xe5, 0x77, 0xe2, 0x6a, 0xf5, 0x44, 0x9d, 0x74, 0xd2, 0x33, 0xb2, 0x20, 0xa0, 0xb5,
+	0x2f, 0x12, 0x41, 0x0d, 0xd5, 0x73, 0xfa, 0x1a, 0x5e, 0x61, 0x0d, 0x2f, 0x10, 0x91, 0xf7, 0xc5,
+	0xb5, 0x9a, 0xa3, 0xfb, 0x4a, 0xae, 0x90, 0x92, 0xff, 0x91, 0x92, 0xdf, 0x88, 0x2b, 0x15, 0xa7,
+	0xf8, 0x3a, 0xae, 0x92, 0x8e, 0x17, 0x48, 0x60, 0xad, 0xd3, 0x41, 0x1f, 0x02, 0x23, 0x78, 0x95,
+	0xc4, 0x0b, 0x20, 0xcf, 0x89, 0xa7, 0x89, 0xb6, 0x19, 0x22, 0xaa, 0x17, 0xbc, 0xe6, 0xe5, 0x84,
+	0x87, 0x63, 0x9c, 0x79, 0x8f, 0xb6, 0x8a, 0x8f, 0x0f, 0xc0, 0xe1, 0xfd, 0xc8, 0x82, 0xf8, 0x35,
+	0x8e, 0x73, 0x0d, 0x4b, 0x7e, 0x26, 0x5e, 0x2e, 0x59, 0xf5, 0xe4, 0xe8, 0x17, 0xd5, 0xb5, 0xfa,
+	0x14, 0x2a, 0xf0, 0x75, 0x5a, 0x35, 0x8b, 0x2d, 0xb7, 0xc4, 0xc5, 0x12, 0x0b, 0xae, 0x27, 0xa3,
+	0xa0, 0x6d, 0x5c, 0xa7, 0x65, 0xb5, 0x3c, 0xac, 0x80, 0x67, 0x1a, 0x5f, 0x39, 0xed, 0x6e, 0x6a,
+	0x54, 0xf0, 0x06, 0x57, 0x80, 0x07, 0x51, 0xde, 0x41, 0xf1, 0xee, 0xee, 0x42, 0xd9, 0x3e, 0xb1,
+	0x03, 0x78, 0x13, 0x05, 0x2d, 0x97, 0x77, 0x65, 0xb8, 0x52, 0x2b, 0x9d, 0xe8, 0x44, 0x25, 0xc1,
+	0x9b, 0x53, 0xb5, 0x42, 0xb8, 0xdc, 0x14, 0x92, 0x12, 0xa1, 0x2c, 0x7d, 0x83, 0xa4, 0x6b, 0x38,
+	0xd3, 0xf5, 0xc2, 0x0b, 0xde, 0xaa, 0xab, 0x17, 0x5e, 0x51, 0xaa, 0x17, 0x96, 0xbe, 0x59, 0xad,
+	0x17, 0x96, 0x84, 0x2e, 0xf1, 0x20, 0x8d, 0x7b, 0xf7, 0x22, 0x93, 0x40, 0x68, 0x6e, 0x71, 0x97,
+	0x28, 0x10, 0xcc, 0x06, 0xa4, 0xa8, 0x7d, 0x05, 0xb7, 0x39, 0x1b, 0x72, 0x80, 0xfa, 0x14, 0x39,
+	0x93, 0x7b, 0xc8, 0xdb, 0xae, 0x4f, 0x15, 0x50, 0xcd, 0xc3, 0xe7, 0x9d, 0xda, 0x87, 0x0f, 0xf8,
+	0x84, 0xfc, 0x74, 0xa0, 0x13, 0xcc, 0x42, 0x04, 0xc1, 0x9e, 0x77, 0xd9, 0x27, 0xd3, 0x1c, 0x8c,
+	0xf7, 0x63, 0x35, 0xb6, 0xa6, 0xba, 0xe2, 0x3d, 0x8e, 0x77, 0x1d, 0x0f, 0x2b, 0xac, 0x0e, 0xc7,
+	0xfb, 0xff, 0xfb, 0x71, 0xd2, 0x87, 0x6b, 0xfa, 0x7d, 0xae, 0xb0, 0x17, 0x4b, 0xc9, 0x87, 0xe2,
+	0xf5, 0x3a, 0x89, 0x7b, 0x89, 0x1a, 0x9e, 0x39, 0x45, 0x9b, 0xa4, 0xe8, 0xff, 0xc4, 0xa8, 0x56,
+	0xf1, 0x6c, 0x94, 0x4d, 0x26, 0xfd, 0xb5, 0xb7, 0x9b, 0x26, 0xd6, 0xa4, 0x31, 0xb7, 0xb8, 0x0f,
+	0x5c, 0xad, 0xce, 0x94, 0xc8, 0xdf, 0x3a, 0xfb, 0x70, 0xe9, 0xc7, 0xc1, 0x87, 0x1c, 0xbd, 0x02,
+	0xc1, 0x7a, 0xe2, 0x74, 0xfe, 0x29, 0x32, 0xbd, 0x6c, 0x27, 0x1d, 0xf7, 0x07, 0xf0, 0x40, 0xc6,
+	0x29, 0x20, 0xb8, 0xc3, 0xf5, 0x34, 0x83, 0x8d, 0x77, 0x5b, 0x1b, 0xde, 0x14, 0x76, 0x7a, 0xe1,
+	0x16, 0xdf, 0x6d, 0xf5, 0x5c, 0xcc, 0x08, 0x02, 0x61, 0x82, 0xe9, 0x42, 0x38, 0xee, 0x72, 0x46,
+	0x78, 0x50, 0x2e, 0xe1, 0x02, 0xf6, 0x91, 0x27, 0xe1, 0xe2, 0x34, 0x79, 0xe0, 0xd3, 0x38, 0x40,
+	0xf7, 0xf4, 0xc7, 0xde, 0x03, 0x3f, 0x47, 0xb1, 0xe6, 0x0a, 0x84, 0x1f, 0x06, 0xc1, 0x27, 0x24,
+	0x39, 0x85, 0xe7, 0x3a, 0xf3, 0x37, 0x79, 0xf0, 0xa9, 0xa7, 0x33, 0x47, 0x73, 0x9d, 0x84, 0x38,
+	0x9d, 0x9f, 0x79, 0x3a, 0x3d, 0x1c, 0x73, 0xb6, 0x3c, 0x72, 0x90, 0xde, 0xcf, 0x49, 0xba, 0x86,
+	0x83, 0x39, 0x5b, 0x46, 0x9d, 0xfe, 0x2f, 0x68, 0x45, 0x2d, 0x4f, 0xbe, 0x27, 0x36, 0x76, 0xd3,
+	0xe1, 0x51, 0x64, 0xc1, 0x7b, 0x67, 0xca, 0x70, 0xa7, 0xfa, 0x92, 0x7c, 0x36, 0xcd, 0xc0, 0x1d,
+	0xf2, 0x9e, 0xe8, 0x2f, 0xf8, 0x8a, 0xab, 0xa2, 0x8e, 0x97, 0x77, 0x2e, 0x5f, 0xfe, 0x6b, 0xaf,
+	0x73, 0xf9, 0xb2, 0xe0, 0x45, 0xc2, 0x88, 0x0a, 0xa3, 0xe4, 0x24, 0xf8, 0x86, 0xab, 0xb9, 0x8c,
+	0xa2, 0xce, 0x43, 0x65, 0x4e, 0x0e, 0x8d, 0x1e, 0x46, 0xe6, 0xac, 0x6d, 0xcf, 0xa0, 0x24, 0xbe,
+	0x65, 0x9d, 0x55, 0x1c, 0x67, 0x06, 0xc4, 0xda, 0xe3, 0x23, 0x96, 0xfb, 0x8e, 0x07, 0x77, 0x1f,
+	0xc3, 0x21, 0x18, 0xe9, 0x2c, 0xd8, 0x76, 0x43, 0x30, 0x52, 0x21, 0x63, 0xad, 0x50, 0x2c, 0xe0,
+	0x1f, 0xb9, 0x2e, 0xe6, 0xf3, 0xe9, 0x14, 0xfe, 0xe1, 0xdb, 0xfb, 0x59, 0x64, 0xee, 0xb8, 0xa7,
+	0x2c, 0xfd, 0x77, 0xd8, 0x96, 0x9b, 0x2b, 0xe8, 0xbf, 0xc3, 0xee, 0xba, 0x2f, 0x06, 0xf4, 0xbf,
+	0xf5, 0xdb, 0x9c, 0x58, 0xc6, 0x92, 0x83, 0xd7, 0x9e, 0x9a, 0xf9, 0xc9, 0xa2, 0x3c, 0xba, 0xcf,
+	0x4f, 0x8d, 0xee, 0xf0, 0x8a, 0xbc, 0x6f, 0x50, 0x34, 0x81, 0x87, 0xf2, 0x29, 0xdc, 0xa6, 0xfc,
+	0xdd, 0xa2, 0x0c, 0x82, 0x96, 0x45, 0x02, 0xf8, 0x61, 0xbd, 0xba, 0xb5, 0xb8, 0x49, 0x64, 0xe8,
+	0xd0, 0xd6, 0xef, 0x73, 0xa2, 0x49, 0x7f, 0xe9, 0x66, 0x06, 0x9b, 0x32, 0x0b, 0xf5, 0xed, 0x4c,
+	0x29, 0x00, 0xf9, 0xad, 0xd8, 0xf0, 0xde, 0xf9, 0x4e, 0xe5, 0x3c, 0xa9, 0x2c, 0xcd, 0x2c, 0xac,
+	0x7d, 0x5a, 0x16, 0x82, 0xbb, 0x78, 0xef, 0x54, 0xe1, 0x77, 0x11, 0x9e, 0xd9, 0xd7, 0x37, 0x27,
+	0x1e, 0x20, 0x38, 0x74, 0xdc, 0xd6, 0x1f, 0xf3, 0xa5, 0x89, 0x82, 0x6d, 0x83, 0xb3, 0x7a, 0x58,
+	0x1e, 0x87, 0x32, 0x28, 0xd7, 0xc4, 0xdc, 0x73, 0x17, 0x8f, 0xb9, 0xe7, 0x48, 0xfd, 0xec, 0x22,
+	0x31, 0xf7, 0x33, 0xf6, 0x85, 0x5d, 0xbc, 0x38, 0x12, 0x8b, 0xf7, 0x8b, 0x8b, 0x86, 0x0f, 0xe5,
+	0x2f, 0x13, 0xe2, 0x37, 0xbd, 0x97, 0x09, 0x71, 0x61, 0xb2, 0xe0, 0x36, 0xc8, 0x63, 0x1d, 0x13,
+	0x98, 0x14, 0xcf, 0x0f, 0xdd, 0xb0, 0x01, 0xff, 0xd0, 0xce, 0xf2, 0x85, 0xc1, 0x03, 0x47, 0x19,
+	0xc4, 0x1b, 0x97, 0x3b, 0x74, 0x59, 0x96, 0x67, 0x90, 0x3a, 0x56, 0xeb, 0xdf, 0x05, 0x71, 0xae,
+	0xe4, 0x2e, 0x4c, 0x2b, 0xfa, 0xe0, 0xc3, 0x33, 0x30, 0xfd, 0x2f, 0x47, 0x70, 0xbe, 0x1a, 0xc1,
+	0x29, 0x1f, 0x36, 0x66, 0xfa, 0x70, 0xa1, 0xe4, 0xc3, 0xe6, 0xc4, 0x87, 0x30, 0xab, 0x93, 0x3d,
+	0x26, 0x9f, 0x6e, 0x73, 0x1a, 0x79, 0xcf, 0x34, 0x54, 0x3f, 0xe6, 0x2a, 0xfb, 0x23, 0xa7, 0xf1,
+	0x9e, 0xe0, 0x7e, 0x03, 0x0f, 0x9a, 0xd2, 0x6e, 0x38, 0x90, 0xe1, 0xf4, 0x36, 0x8b, 0x8d, 0xb5,
+	0x81, 0x83, 0x1d, 0xe8, 0x64, 0xe7, 0x38, 0x8a, 0x26, 0xb7, 0x63, 0x4b, 0x86, 0x08, 0x37, 0xb9,
+	0x31, 0x89, 0x76, 0xec, 0x28, 0xa8, 0x10, 0xac, 0x27, 0x9e, 0xbf, 0x72, 0x1a, 0xfd, 0xd3, 0x3e,
+	0x01, 0x83, 0xdb, 0x71, 0x6a, 0xdd, 0xcc, 0x55, 0x00, 0x98, 0x21, 0x14, 0xd4, 0xa7, 0x23, 0x72,
+	0xac, 0xfb, 0xa6, 0xe5, 0x41, 0xa8, 0x1b, 0x2f, 0x12, 0x62, 0xaf, 0xf3, 0x87, 0xb6, 0x09, 0x8d,
+	0xba, 0xe9, 0x63, 0x61, 0x8a, 0x36, 0xf1, 0x44, 0x55, 0x00, 0xde, 0x57, 0x81, 0xf3, 0xa5, 0xaf,
+	0x02, 0xd0, 0x9d, 0x76, 0xc6, 0x3a, 0xee, 0xc1, 0xc9, 0x49, 0xeb, 0x06, 0x7f, 0xd1, 0xf0, 0x31,
+	0xdc, 0x15, 0x87, 0x7d, 0xe2, 0x4b, 0xde, 0x75, 0x42, 0x73, 0xce, 0xc2, 0x95, 0x41, 0xcc, 0x0b,
+	0xc4, 0x2c, 0x00, 0x3c, 0xd1, 0x01, 0xe4, 0x90, 0x75, 0xfc, 0x8b, 0x7c, 0x22, 0x0f, 0xc2, 0x8e,
+	0xeb, 0x48, 0x68, 0x86, 0x24, 0x74, 0x89, 0x84, 0x2a, 0xe8, 0xd1, 0x22, 0x7d, 0x67, 0xbd, 0xfb,
+	0x1f, 0x90, 0xa9, 0x66, 0xbb, 0x7c, 0x15, 0x00, 0x00,
 }
@@ -19,7 +19,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='proto/match.proto',
   package='',
   syntax='proto3',
-  serialized_pb=_b('\n\x11proto/match.proto\"\xba\x01\n\x05Match\x12\x0e\n\x06GameID\x18\x01 \x01(\x03\x12\x10\n\x08SeasonID\x18\x02 \x01(\x05\x12\x14\n\x0cGameCreation\x18\x03 \x01(\x03\x12\x14\n\x0cGameDuration\x18\x04 \x01(\x05\x12\"\n\x0cParticipants\x18\x05 \x03(\x0b\x32\x0c.Participant\x12\x0c\n\x04\x42\x61ns\x18\x06 \x03(\x03\x12\x10\n\x08GameMode\x18\x07 \x01(\t\x12\r\n\x05MapID\x18\x08 \x01(\x05\x12\x10\n\x08GameType\x18\t \x01(\t\"\xb5\x01\n\x0bParticipant\x12\x14\n\x0cSummonerName\x18\x01 \x01(\t\x12\x11\n\tAccountID\x18\x02 \x01(\x03\x12\x13\n\x0bProfileIcon\x18\x03 \x01(\x05\x12\x12\n\nSummonerID\x18\x04 \x01(\x03\x12\x12\n\nChampionID\x18\x05 \x01(\x03\x12\x0e\n\x06TeamID\x18\x06 \x01(\x05\x12\x0e\n\x06Winner\x18\x07 \x01(\x08\x12 \n\x05Stats\x18\x08 \x01(\x0b\x32\x11.ParticipantStats\"\xb6\x0c\n\x10ParticipantStats\x12\x0e\n\x06Spell1\x18\x01 \x01(\x05\x12\x0e\n\x06Spell2\x18\x02 \x01(\x05\x12\x11\n\tmasteries\x18\x04 \x03(\x05\x12\r\n\x05Runes\x18\x05 \x03(\x05\x12\r\n\x05Items\x18\x06 \x03(\x05\x12\r\n\x05Kills\x18\x07 \x01(\x05\x12\x0e\n\x06\x44\x65\x61ths\x18\x08 \x01(\x05\x12\x0f\n\x07\x41ssists\x18\t \x01(\x05\x12\x1b\n\x13LargestKillingSpree\x18\n \x01(\x05\x12\x18\n\x10LargestMultiKill\x18\x0b \x01(\x05\x12\x15\n\rKillingSprees\x18\x0c \x01(\x05\x12\x1e\n\x16LongestTimeSpentLiving\x18\r \x01(\x05\x12\x13\n\x0b\x44oubleKills\x18\x0e \x01(\x05\x12\x13\n\x0bTripleKills\x18\x0f \x01(\x05\x12\x13\n\x0bQuadraKills\x18\x10 \x01(\x05\x12\x12\n\nPentaKills\x18\x11 \x01(\x05\x12\x13\n\x0bUnrealKills\x18\x12 \x01(\x05\x12\x18\n\x10TotalDamageDealt\x18\x13 \x01(\x05\x12\x18\n\x10MagicDamageDealt\x18\x14 \x01(\x05\x12\x1b\n\x13PhysicalDamageDealt\x18\x15 \x01(\x05\x12\x17\n\x0fTrueDamageDealt\x18\x16 \x01(\x05\x12\x1d\n\x15LargestCriticalStrike\x18\x17 \x01(\x05\x12#\n\x1bTotalDamageDealtToChampions\x18\x18 \x01(\x05\x12#\n\x1bMagicDamageDealtToChampions\x18\x19 \x01(\x05\x12&\n\x1ePhysicalDamageDealtToChampions\x18\x1a \x01(\x05\x12\"\n\x1aTrueDamageDealtToChampions\x18\x1b \x01(\x05\x12\x11\n\tTotalHeal\x18\x1c \x01(\x05\x12\x18\n\x10TotalUnitsHealed\x18\x1d \x01(\x05\x12\x1b\n\x13\x44\x61mageSelfMitigated\x18\x1e \x01(\x05\x12\x1f\n\x17\x44\x61mageDealtToObjectives\x18\x1f \x01(\x05\x12\x1c\n\x14\x44\x61mageDealtToTurrets\x18  \x01(\x05\x12\x13\n\x0bVisionScore\x18! \x01(\x05\x12\x17\n\x0fTimeCCingOthers\x18\" \x01(\x05\x12\x18\n\x10TotalDamageTaken\x18# \x01(\x05\x12\x1a\n\x12MagicalDamageTaken\x18$ \x01(\x05\x12\x1b\n\x13PhysicalDamageTaken\x18% \x01(\x05\x12\x17\n\x0fTrueDamageTaken\x18& \x01(\x05\x12\x12\n\nGoldEarned\x18\' \x01(\x05\x12\x11\n\tGoldSpent\x18( \x01(\x05\x12\x13\n\x0bTurretKills\x18) \x01(\x05\x12\x16\n\x0eInhibitorKills\x18* \x01(\x05\x12\x1a\n\x12TotalMinionsKilled\x18+ \x01(\x05\x12\x1c\n\x14NeutralMinionsKilled\x18, \x01(\x05\x12&\n\x1eNeutralMinionsKilledTeamJungle\x18- \x01(\x05\x12\'\n\x1fNeutralMinionsKilledEnemyJungle\x18. \x01(\x05\x12\"\n\x1aTotalTimeCrowdControlDealt\x18/ \x01(\x05\x12\x12\n\nChampLevel\x18\x30 \x01(\x05\x12\x1f\n\x17VisionWardsBoughtInGame\x18\x31 \x01(\x05\x12\x1e\n\x16SightWardsBoughtInGame\x18\x32 \x01(\x05\x12\x13\n\x0bWardsPlaced\x18\x33 \x01(\x05\x12\x13\n\x0bWardsKilled\x18\x34 \x01(\x05\x12\x16\n\x0e\x46irstBloodKill\x18\x35 \x01(\x08\x12\x18\n\x10\x46irstBloodAssist\x18\x36 \x01(\x08\x12\x16\n\x0e\x46irstTowerKill\x18\x37 \x01(\x08\x12\x18\n\x10\x46irstTowerAssist\x18\x38 \x01(\x08\x12\x1a\n\x12\x46irstInhibitorKill\x18\x39 \x01(\x08\x12\x1c\n\x14\x46irstInhibitorAssist\x18: \x01(\x08\x12\x19\n\x11\x43ombatPlayerScore\x18; \x01(\x05\x12\x1c\n\x14ObjectivePlayerScore\x18< \x01(\x05\x12\x18\n\x10TotalPlayerScore\x18= \x01(\x05\x12\x16\n\x0eTotalScoreRank\x18> \x01(\x05\x62\x06proto3')
+  serialized_pb=_b('\n\x11proto/match.proto"\xa1\x02\n\x05Match\x12\x0e\n\x06GameID\x18\x01 \x01(\x03\x12\x10\n\x08SeasonID\x18\x02 \x01(\x05\x12\x14\n\x0cGameCreation\x18\x03 \x01(\x03\x12\x14\n\x0cGameDuration\x18\x04 \x01(\x05\x12\"\n\x0cParticipants\x18\x05 \x03(\x0b\x32\x0c.Participant\x12\x0c\n\x04\x42\x61ns\x18\x06 \x03(\x03\x12\x10\n\x08GameMode\x18\x07 \x01(\t\x12\r\n\x05MapID\x18\x08 \x01(\x05\x12\x10\n\x08GameType\x18\t \x01(\t\x12\x12\n\nPlatformID\x18\n \x01(\t\x12\x14\n\x05Teams\x18\x0b \x03(\x0b\x32\x05.Team\x12\x0f\n\x07QueueID\x18\x0c \x01(\x05\x12\x13\n\x0bGameVersion\x18\r \x01(\t\x12\x15\n\rSchemaVersion\x18\x0e \x01(\x05\"\xaa\x02\n\x04Team\x12\x0e\n\x06TeamID\x18\x01 \x01(\x05\x12\x0e\n\x06Winner\x18\x02 \x01(\x08\x12\x12\n\x04\x42\x61ns\x18\x03 \x03(\x0b\x32\x04.Ban\x12\x12\n\nFirstBlood\x18\x04 \x01(\x08\x12\x12\n\nFirstTower\x18\x05 \x01(\x08\x12\x16\n\x0e\x46irstInhibitor\x18\x06 \x01(\x08\x12\x12\n\nFirstBaron\x18\x07 \x01(\x08\x12\x13\n\x0b\x46irstDragon\x18\x08 \x01(\x08\x12\x17\n\x0f\x46irstRiftHerald\x18\t \x01(\x08\x12\x12\n\nTowerKills\x18\n \x01(\x05\x12\x16\n\x0eInhibitorKills\x18\x0b \x01(\x05\x12\x12\n\nBaronKills\x18\x0c \x01(\x05\x12\x13\n\x0b\x44ragonKills\x18\r \x01(\x05\x12\x17\n\x0fRiftHeraldKills\x18\x0e \x01(\x05\"+\n\x03\x42\x61n\x12\x12\n\nChampionID\x18\x01 \x01(\x03\x12\x10\n\x08PickTurn\x18\x02 \x01(\x05\"\x94\x02\n\x0bParticipant\x12\x14\n\x0cSummonerName\x18\x01 \x01(\t\x12\x11\n\tAccountID\x18\x02 \x01(\x03\x12\x13\n\x0bProfileIcon\x18\x03 \x01(\x05\x12\x12\n\nSummonerID\x18\x04 \x01(\x03\x12\x12\n\nChampionID\x18\x05 \x01(\x03\x12\x0e\n\x06TeamID\x18\x06 \x01(\x05\x12\x0e\n\x06Winner\x18\x07 \x01(\x08\x12 \n\x05Stats\x18\x08 \x01(\x0b\x32\x11.ParticipantStats\x12\x0c\n\x04Lane\x18\t \x01(\t\x12\x0c\n\x04Role\x18\n \x01(\t\x12\x0e\n\x06Spell1\x18\x0b \x01(\x05\x12\x0e\n\x06Spell2\x18\x0c \x01(\x05\x12!\n\x19HighestAchievedSeasonTier\x18\r \x01(\t\"\xfc\x0c\n\x10ParticipantStats\x12\x0e\n\x06Spell1\x18\x01 \x01(\x05\x12\x0e\n\x06Spell2\x18\x02 \x01(\x05\x12\x11\n\tmasteries\x18\x04 \x03(\x05\x12\r\n\x05Runes\x18\x05 \x03(\x05\x12\r\n\x05Items\x18\x06 \x03(\x05\x12\r\n\x05Kills\x18\x07 \x01(\x05\x12\x0e\n\x06\x44\x65\x61ths\x18\x08 \x01(\x05\x12\x0f\n\x07\x41ssists\x18\t \x01(\x05\x12\x1b\n\x13LargestKillingSpree\x18\n \x01(\x05\x12\x18\n\x10LargestMultiKill\x18\x0b \x01(\x05\x12\x15\n\rKillingSprees\x18\x0c \x01(\x05\x12\x1e\n\x16LongestTimeSpentLiving\x18\r \x01(\x05\x12\x13\n\x0b\x44oubleKills\x18\x0e \x01(\x05\x12\x13\n\x0bTripleKills\x18\x0f \x01(\x05\x12\x13\n\x0bQuadraKills\x18\x10 \x01(\x05\x12\x12\n\nPentaKills\x18\x11 \x01(\x05\x12\x13\n\x0bUnrealKills\x18\x12 \x01(\x05\x12\x18\n\x10TotalDamageDealt\x18\x13 \x01(\x05\x12\x18\n\x10MagicDamageDealt\x18\x14 \x01(\x05\x12\x1b\n\x13PhysicalDamageDealt\x18\x15 \x01(\x05\x12\x17\n\x0fTrueDamageDealt\x18\x16 \x01(\x05\x12\x1d\n\x15LargestCriticalStrike\x18\x17 \x01(\x05\x12#\n\x1bTotalDamageDealtToChampions\x18\x18 \x01(\x05\x12#\n\x1bMagicDamageDealtToChampions\x18\x19 \x01(\x05\x12&\n\x1ePhysicalDamageDealtToChampions\x18\x1a \x01(\x05\x12\"\n\x1aTrueDamageDealtToChampions\x18\x1b \x01(\x05\x12\x11\n\tTotalHeal\x18\x1c \x01(\x05\x12\x18\n\x10TotalUnitsHealed\x18\x1d \x01(\x05\x12\x1b\n\x13\x44\x61mageSelfMitigated\x18\x1e \x01(\x05\x12\x1f\n\x17\x44\x61mageDealtToObjectives\x18\x1f \x01(\x05\x12\x1c\n\x14\x44\x61mageDealtToTurrets\x18  \x01(\x05\x12\x13\n\x0bVisionScore\x18! \x01(\x05\x12\x17\n\x0fTimeCCingOthers\x18\" \x01(\x05\x12\x18\n\x10TotalDamageTaken\x18# \x01(\x05\x12\x1a\n\x12MagicalDamageTaken\x18$ \x01(\x05\x12\x1b\n\x13PhysicalDamageTaken\x18% \x01(\x05\x12\x17\n\x0fTrueDamageTaken\x18& \x01(\x05\x12\x12\n\nGoldEarned\x18\' \x01(\x05\x12\x11\n\tGoldSpent\x18( \x01(\x05\x12\x13\n\x0bTurretKills\x18) \x01(\x05\x12\x16\n\x0eInhibitorKills\x18* \x01(\x05\x12\x1a\n\x12TotalMinionsKilled\x18+ \x01(\x05\x12\x1c\n\x14NeutralMinionsKilled\x18, \x01(\x05\x12&\n\x1eNeutralMinionsKilledTeamJungle\x18- \x01(\x05\x12\'\n\x1fNeutralMinionsKilledEnemyJungle\x18. \x01(\x05\x12\"\n\x1aTotalTimeCrowdControlDealt\x18/ \x01(\x05\x12\x12\n\nChampLevel\x18\x30 \x01(\x05\x12\x1f\n\x17VisionWardsBoughtInGame\x18\x31 \x01(\x05\x12\x1e\n\x16SightWardsBoughtInGame\x18\x32 \x01(\x05\x12\x13\n\x0bWardsPlaced\x18\x33 \x01(\x05\x12\x13\n\x0bWardsKilled\x18\x34 \x01(\x05\x12\x16\n\x0e\x46irstBloodKill\x18\x35 \x01(\x08\x12\x18\n\x10\x46irstBloodAssist\x18\x36 \x01(\x08\x12\x16\n\x0e\x46irstTowerKill\x18\x37 \x01(\x08\x12\x18\n\x10\x46irstTowerAssist\x18\x38 \x01(\x08\x12\x1a\n\x12\x46irstInhibitorKill\x18\x39 \x01(\x08\x12\x1c\n\x14\x46irstInhibitorAssist\x18: \x01(\x08\x12\x19\n\x11\x43ombatPlayerScore\x18; \x01(\x05\x12\x1c\n\x14ObjectivePlayerScore\x18< \x01(\x05\x12\x18\n\x10TotalPlayerScore\x18= \x01(\x05\x12\x16\n\x0eTotalScoreRank\x18> \x01(\x05\x12\x18\n\x10PerkPrimaryStyle\x18? \x01(\x05\x12\x14\n\x0cPerkSubStyle\x18@ \x01(\x05\x12\x14\n\x05Perks\x18\x41 \x03(\x0b\x32\x05.Perk\"<\n\x04Perk\x12\n\n\x02ID\x18\x01 \x01(\x05\x12\x0c\n\x04Var1\x18\x02 \x01(\x05\x12\x0c\n\x04Var2\x18\x03 \x01(\x05\x12\x0c\n\x04Var3\x18\x04 \x01(\x05\"]\n\x08Timeline\x12\x0e\n\x06GameID\x18\x01 \x01(\x03\x12\x12\n\nPlatformID\x18\x02 \x01(\t\x12\x15\n\rFrameInterval\x18\x03 \x01(\x03\x12\x16\n\x06\x46rames\x18\x04 \x03(\x0b\x32\x06.Frame\"h\n\x05\x46rame\x12\x11\n\tTimestamp\x18\x01 \x01(\x03\x12,\n\x11ParticipantFrames\x18\x02 \x03(\x0b\x32\x11.ParticipantFrame\x12\x1e\n\x06\x45vents\x18\x03 \x03(\x0b\x32\x0e.TimelineEvent\"\xb6\x01\n\x10ParticipantFrame\x12\x15\n\rParticipantID\x18\x01 \x01(\x05\x12\t\n\x01X\x18\x02 \x01(\x05\x12\t\n\x01Y\x18\x03 \x01(\x05\x12\x13\n\x0b\x43urrentGold\x18\x04 \x01(\x05\x12\x11\n\tTotalGold\x18\x05 \x01(\x05\x12\r\n\x05Level\x18\x06 \x01(\x05\x12\n\n\x02XP\x18\x07 \x01(\x05\x12\x15\n\rMinionsKilled\x18\x08 \x01(\x05\x12\x1b\n\x13JungleMinionsKilled\x18\t \x01(\x05\"\x9a\x03\n\rTimelineEvent\x12\x0c\n\x04Type\x18\x01 \x01(\t\x12\x11\n\tTimestamp\x18\x02 \x01(\x03\x12\x15\n\rParticipantID\x18\x03 \x01(\x05\x12\t\n\x01X\x18\x04 \x01(\x05\x12\t\n\x01Y\x18\x05 \x01(\x05\x12\x10\n\x08KillerID\x18\x06 \x01(\x05\x12\x10\n\x08VictimID\x18\x07 \x01(\x05\x12\x1f\n\x17\x41ssistingParticipantIDs\x18\x08 \x03(\x05\x12\x0e\n\x06ItemID\x18\t \x01(\x05\x12\x0f\n\x07\x41\x66terID\x18\n \x01(\x05\x12\x10\n\x08\x42\x65\x66oreID\x18\x0b \x01(\x05\x12\x11\n\tSkillSlot\x18\x0c \x01(\x05\x12\x13\n\x0bLevelUpType\x18\r \x01(\t\x12\x10\n\x08WardType\x18\x0e \x01(\t\x12\x11\n\tCreatorID\x18\x0f \x01(\x05\x12\x0e\n\x06TeamID\x18\x10 \x01(\x05\x12\x14\n\x0c\x42uildingType\x18\x11 \x01(\t\x12\x10\n\x08LaneType\x18\x12 \x01(\t\x12\x11\n\tTowerType\x18\x13 \x01(\t\x12\x13\n\x0bMonsterType\x18\x14 \x01(\t\x12\x16\n\x0eMonsterSubType\x18\x15 \x01(\tb\x06proto3')
 )
 
 
@@ -95,6 +95,41 @@ _MATCH = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='PlatformID', full_name='Match.PlatformID', index=9,
+      number=10, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Teams', full_name='Match.Teams', index=10,
+      number=11, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='QueueID', full_name='Match.QueueID', index=11,
+      number=12, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='GameVersion', full_name='Match.GameVersion', index=12,
+      number=13, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='SchemaVersion', full_name='Match.SchemaVersion', index=13,
+      number=14, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -108,7 +143,167 @@ _MATCH = _descriptor.Descriptor(
   oneofs=[
   ],
   serialized_start=22,
-  serialized_end=208,
+  serialized_end=311,
+)
+
+
+_TEAM = _descriptor.Descriptor(
+  name='Team',
+  full_name='Team',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='TeamID', full_name='Team.TeamID', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Winner', full_name='Team.Winner', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Bans', full_name='Team.Bans', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='FirstBlood', full_name='Team.FirstBlood', index=3,
+      number=4, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='FirstTower', full_name='Team.FirstTower', index=4,
+      number=5, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='FirstInhibitor', full_name='Team.FirstInhibitor', index=5,
+      number=6, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='FirstBaron', full_name='Team.FirstBaron', index=6,
+      number=7, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='FirstDragon', full_name='Team.FirstDragon', index=7,
+      number=8, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='FirstRiftHerald', full_name='Team.FirstRiftHerald', index=8,
+      number=9, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='TowerKills', full_name='Team.TowerKills', index=9,
+      number=10, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='InhibitorKills', full_name='Team.InhibitorKills', index=10,
+      number=11, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='BaronKills', full_name='Team.BaronKills', index=11,
+      number=12, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='DragonKills', full_name='Team.DragonKills', index=12,
+      number=13, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='RiftHeraldKills', full_name='Team.RiftHeraldKills', index=13,
+      number=14, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=314,
+  serialized_end=612,
+)
+
+
+_BAN = _descriptor.Descriptor(
+  name='Ban',
+  full_name='Ban',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ChampionID', full_name='Ban.ChampionID', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='PickTurn', full_name='Ban.PickTurn', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=614,
+  serialized_end=657,
 )
 
 
@@ -175,6 +370,41 @@ _PARTICIPANT = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='Lane', full_name='Participant.Lane', index=8,
+      number=9, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Role', full_name='Participant.Role', index=9,
+      number=10, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Spell1', full_name='Participant.Spell1', index=10,
+      number=11, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Spell2', full_name='Participant.Spell2', index=11,
+      number=12, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='HighestAchievedSeasonTier', full_name='Participant.HighestAchievedSeasonTier', index=12,
+      number=13, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -187,8 +417,8 @@ _PARTICIPANT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=211,
-  serialized_end=392,
+  serialized_start=660,
+  serialized_end=936,
 )
 
 
@@ -626,6 +856,27 @@ _PARTICIPANTSTATS = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='PerkPrimaryStyle', full_name='ParticipantStats.PerkPrimaryStyle', index=61,
+      number=63, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='PerkSubStyle', full_name='ParticipantStats.PerkSubStyle', index=62,
+      number=64, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Perks', full_name='ParticipantStats.Perks', index=63,
+      number=65, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -638,30 +889,464 @@ _PARTICIPANTSTATS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=395,
-  serialized_end=1985,
+  serialized_start=939,
+  serialized_end=2599,
 )
 
-_MATCH.fields_by_name['Participants'].message_type = _PARTICIPANT
-_PARTICIPANT.fields_by_name['Stats'].message_type = _PARTICIPANTSTATS
-DESCRIPTOR.message_types_by_name['Match'] = _MATCH
-DESCRIPTOR.message_types_by_name['Participant'] = _PARTICIPANT
-DESCRIPTOR.message_types_by_name['ParticipantStats'] = _PARTICIPANTSTATS
-_sym_db.RegisterFileDescriptor(DESCRIPTOR)
 
-Match = _reflection.GeneratedProtocolMessageType('Match', (_message.Message,), dict(
-  DESCRIPTOR = _MATCH,
-  __module__ = 'proto.match_pb2'
-  # @@protoc_insertion_point(class_scope:Match)
-  ))
-_sym_db.RegisterMessage(Match)
+_PERK = _descriptor.Descriptor(
+  name='Perk',
+  full_name='Perk',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ID', full_name='Perk.ID', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Var1', full_name='Perk.Var1', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Var2', full_name='Perk.Var2', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Var3', full_name='Perk.Var3', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2601,
+  serialized_end=2661,
+)
 
-Participant = _reflection.GeneratedProtocolMessageType('Participant', (_message.Message,), dict(
-  DESCRIPTOR = _PARTICIPANT,
-  __module__ = 'proto.match_pb2'
-  # @@protoc_insertion_point(class_scope:Participant)
-  ))
-_sym_db.RegisterMessage(Participant)
+
+_TIMELINE = _descriptor.Descriptor(
+  name='Timeline',
+  full_name='Timeline',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='GameID', full_name='Timeline.GameID', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='PlatformID', full_name='Timeline.PlatformID', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='FrameInterval', full_name='Timeline.FrameInterval', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Frames', full_name='Timeline.Frames', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2663,
+  serialized_end=2756,
+)
+
+
+_FRAME = _descriptor.Descriptor(
+  name='Frame',
+  full_name='Frame',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='Timestamp', full_name='Frame.Timestamp', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ParticipantFrames', full_name='Frame.ParticipantFrames', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Events', full_name='Frame.Events', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2758,
+  serialized_end=2862,
+)
+
+
+_PARTICIPANTFRAME = _descriptor.Descriptor(
+  name='ParticipantFrame',
+  full_name='ParticipantFrame',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='ParticipantID', full_name='ParticipantFrame.ParticipantID', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='X', full_name='ParticipantFrame.X', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Y', full_name='ParticipantFrame.Y', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='CurrentGold', full_name='ParticipantFrame.CurrentGold', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='TotalGold', full_name='ParticipantFrame.TotalGold', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Level', full_name='ParticipantFrame.Level', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='XP', full_name='ParticipantFrame.XP', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='MinionsKilled', full_name='ParticipantFrame.MinionsKilled', index=7,
+      number=8, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='JungleMinionsKilled', full_name='ParticipantFrame.JungleMinionsKilled', index=8,
+      number=9, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2865,
+  serialized_end=3047,
+)
+
+
+_TIMELINEEVENT = _descriptor.Descriptor(
+  name='TimelineEvent',
+  full_name='TimelineEvent',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='Type', full_name='TimelineEvent.Type', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Timestamp', full_name='TimelineEvent.Timestamp', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ParticipantID', full_name='TimelineEvent.ParticipantID', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='X', full_name='TimelineEvent.X', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='Y', full_name='TimelineEvent.Y', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='KillerID', full_name='TimelineEvent.KillerID', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='VictimID', full_name='TimelineEvent.VictimID', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='AssistingParticipantIDs', full_name='TimelineEvent.AssistingParticipantIDs', index=7,
+      number=8, type=5, cpp_type=1, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ItemID', full_name='TimelineEvent.ItemID', index=8,
+      number=9, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='AfterID', full_name='TimelineEvent.AfterID', index=9,
+      number=10, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='BeforeID', full_name='TimelineEvent.BeforeID', index=10,
+      number=11, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='SkillSlot', full_name='TimelineEvent.SkillSlot', index=11,
+      number=12, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='LevelUpType', full_name='TimelineEvent.LevelUpType', index=12,
+      number=13, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='WardType', full_name='TimelineEvent.WardType', index=13,
+      number=14, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='CreatorID', full_name='TimelineEvent.CreatorID', index=14,
+      number=15, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='TeamID', full_name='TimelineEvent.TeamID', index=15,
+      number=16, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='BuildingType', full_name='TimelineEvent.BuildingType', index=16,
+      number=17, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='LaneType', full_name='TimelineEvent.LaneType', index=17,
+      number=18, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='TowerType', full_name='TimelineEvent.TowerType', index=18,
+      number=19, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='MonsterType', full_name='TimelineEvent.MonsterType', index=19,
+      number=20, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='MonsterSubType', full_name='TimelineEvent.MonsterSubType', index=20,
+      number=21, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3050,
+  serialized_end=3460,
+)
+
+_MATCH.fields_by_name['Participants'].message_type = _PARTICIPANT
+_MATCH.fields_by_name['Teams'].message_type = _TEAM
+_TEAM.fields_by_name['Bans'].message_type = _BAN
+_PARTICIPANT.fields_by_name['Stats'].message_type = _PARTICIPANTSTATS
+_PARTICIPANTSTATS.fields_by_name['Perks'].message_type = _PERK
+_TIMELINE.fields_by_name['Frames'].message_type = _FRAME
+_FRAME.fields_by_name['ParticipantFrames'].message_type = _PARTICIPANTFRAME
+_FRAME.fields_by_name['Events'].message_type = _TIMELINEEVENT
+DESCRIPTOR.message_types_by_name['Match'] = _MATCH
+DESCRIPTOR.message_types_by_name['Team'] = _TEAM
+DESCRIPTOR.message_types_by_name['Ban'] = _BAN
+DESCRIPTOR.message_types_by_name['Participant'] = _PARTICIPANT
+DESCRIPTOR.message_types_by_name['ParticipantStats'] = _PARTICIPANTSTATS
+DESCRIPTOR.message_types_by_name['Perk'] = _PERK
+DESCRIPTOR.message_types_by_name['Timeline'] = _TIMELINE
+DESCRIPTOR.message_types_by_name['Frame'] = _FRAME
+DESCRIPTOR.message_types_by_name['ParticipantFrame'] = _PARTICIPANTFRAME
+DESCRIPTOR.message_types_by_name['TimelineEvent'] = _TIMELINEEVENT
+_sym_db.RegisterFileDescriptor(DESCRIPTOR)
+
+Match = _reflection.GeneratedProtocolMessageType('Match', (_message.Message,), dict(
+  DESCRIPTOR = _MATCH,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:Match)
+  ))
+_sym_db.RegisterMessage(Match)
+
+Team = _reflection.GeneratedProtocolMessageType('Team', (_message.Message,), dict(
+  DESCRIPTOR = _TEAM,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:Team)
+  ))
+_sym_db.RegisterMessage(Team)
+
+Ban = _reflection.GeneratedProtocolMessageType('Ban', (_message.Message,), dict(
+  DESCRIPTOR = _BAN,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:Ban)
+  ))
+_sym_db.RegisterMessage(Ban)
+
+Participant = _reflection.GeneratedProtocolMessageType('Participant', (_message.Message,), dict(
+  DESCRIPTOR = _PARTICIPANT,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:Participant)
+  ))
+_sym_db.RegisterMessage(Participant)
 
 ParticipantStats = _reflection.GeneratedProtocolMessageType('ParticipantStats', (_message.Message,), dict(
   DESCRIPTOR = _PARTICIPANTSTATS,
@@ -670,5 +1355,40 @@ ParticipantStats = _reflection.GeneratedProtocolMessageType('ParticipantStats',
   ))
 _sym_db.RegisterMessage(ParticipantStats)
 
+Perk = _reflection.GeneratedProtocolMessageType('Perk', (_message.Message,), dict(
+  DESCRIPTOR = _PERK,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:Perk)
+  ))
+_sym_db.RegisterMessage(Perk)
+
+Timeline = _reflection.GeneratedProtocolMessageType('Timeline', (_message.Message,), dict(
+  DESCRIPTOR = _TIMELINE,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:Timeline)
+  ))
+_sym_db.RegisterMessage(Timeline)
+
+Frame = _reflection.GeneratedProtocolMessageType('Frame', (_message.Message,), dict(
+  DESCRIPTOR = _FRAME,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:Frame)
+  ))
+_sym_db.RegisterMessage(Frame)
+
+ParticipantFrame = _reflection.GeneratedProtocolMessageType('ParticipantFrame', (_message.Message,), dict(
+  DESCRIPTOR = _PARTICIPANTFRAME,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:ParticipantFrame)
+  ))
+_sym_db.RegisterMessage(ParticipantFrame)
+
+TimelineEvent = _reflection.GeneratedProtocolMessageType('TimelineEvent', (_message.Message,), dict(
+  DESCRIPTOR = _TIMELINEEVENT,
+  __module__ = 'proto.match_pb2'
+  # @@protoc_insertion_point(class_scope:TimelineEvent)
+  ))
+_sym_db.RegisterMessage(TimelineEvent)
+
 
 # @@protoc_insertion_point(module_scope)
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// reindex : Rebuild the account, champion and time indexes from the stored matches. New matches
+// are indexed as they're stored, so this is only needed for stores created before indexes were
+// kept.
+func reindex(args []string) {
+	var err error
+	store, err = structs.OpenMatchStore(config.Config.MatchStoreLocation)
+	if err != nil {
+		fmt.Println("Couldn't open match store: " + err.Error())
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	count, err := store.Reindex()
+	if err != nil {
+		fmt.Println("Error rebuilding indexes: " + err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Indexed %d matches\n", count)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/anyweez/matchgrab/crawler"
+)
+
+// displayReporter : Shows progress from all crawlers on the terminal UI. Queue stats are
+// combined across platforms.
+type displayReporter struct {
+	progress map[string]crawler.Progress
+	lock     sync.Mutex
+}
+
+func newDisplayReporter() *displayReporter {
+	return &displayReporter{
+		progress: make(map[string]crawler.Progress),
+	}
+}
+
+func (dr *displayReporter) Event(msg string) {
+	ui.AddEvent(msg)
+}
+
+func (dr *displayReporter) Request(platform string) {
+	requestLog <- time.Now()
+}
+
+func (dr *displayReporter) Progress(p crawler.Progress) {
+	dr.lock.Lock()
+	dr.progress[p.Platform] = p
+
+	summoners := float32(0)
+	matches := float32(0)
+	known := 0
+
+	for _, p := range dr.progress {
+		summoners += p.QueuedSummoners
+		matches += p.QueuedMatches
+		known += p.KnownSummoners
+	}
+
+	platforms := float32(len(dr.progress))
+	dr.lock.Unlock()
+
+	ui.UpdateQueuedSummoners(summoners / platforms * 100)
+	ui.UpdateTotalSummoners(known)
+	ui.UpdateQueuedMatches(matches / platforms * 100)
+	ui.UpdateTotalMatches(store.Count())
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// reprocess : Rebuild all archived matches with the current match format. Only matches crawled
+// with `archive_raw` enabled can be rebuilt; everything else is left as-is.
+func reprocess(args []string) {
+	// Snapshots aren't taken while matches are being rebuilt.
+	var err error
+	store, err = structs.OpenMatchStore(config.Config.MatchStoreLocation)
+	if err != nil {
+		fmt.Println("Couldn't open match store: " + err.Error())
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	count, err := store.Reprocess()
+	if err != nil {
+		fmt.Println("Error reprocessing matches: " + err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Rebuilt %d matches\n", count)
+}
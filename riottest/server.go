@@ -0,0 +1,364 @@
+// Package riottest provides a fake Riot API server for testing crawls without a network
+// connection or an API key. The server generates a deterministic universe of summoners and
+// matches from a seed and serves them from the same match-v3 endpoints that matchgrab uses.
+// Rate limiting, server errors, and latency can be injected to exercise error handling.
+//
+// To crawl against the fake server, set `api_base_url` in config.json (or
+// config.Config.APIBaseURL in tests) to the server's URL.
+package riottest
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options : Describes the universe that a Server generates and the faults it injects. Zero
+// values are replaced with the defaults listed below.
+type Options struct {
+	Seed      int64     // seed for the universe and fault injection (default 1)
+	Platform  string    // platform ID reported in all responses (default NA1)
+	Summoners int       // number of distinct accounts (default 50, minimum 10)
+	Matches   int       // number of matches (default 200)
+	Now       time.Time // creation time of the most recent match (default time.Now())
+
+	// APIKey, if set, is required in the X-Riot-Token header. Other keys get a 403.
+	APIKey string
+
+	// RateLimitEvery returns a 429 for every Nth request. RetryAfter is sent along with it
+	// as the number of seconds to wait.
+	RateLimitEvery int
+	RetryAfter     int
+
+	ServerErrorRate float64       // fraction of requests that fail with a 503
+	Latency         time.Duration // delay before responding to each request
+
+	// IgnoreBeginTime serves matchlists as if beginTime wasn't sent unless endTime is sent
+	// with it, which match-v3 may do.
+	IgnoreBeginTime bool
+}
+
+// Server : A fake Riot API. It embeds an httptest.Server so URL and Close() work the same way.
+type Server struct {
+	*httptest.Server
+
+	opts     Options
+	universe *universe
+	world    sync.RWMutex // guards universe; see Play()
+
+	lock     sync.Mutex
+	faults   *rand.Rand
+	requests int            // all requests received
+	byStatus map[int]int    // requests received by response status
+	byPath   map[string]int // requests received by URL path
+}
+
+// Endpoints served by the fake API.
+const (
+	matchlistPath = "/lol/match/v3/matchlists/by-account/"
+	matchPath     = "/lol/match/v3/matches/"
+	timelinePath  = "/lol/match/v3/timelines/by-match/"
+)
+
+// Riot never returns more than this many matches in a single matchlist response.
+const maxMatchlist = 100
+
+// NewServer : Generate a universe and start serving it. Callers should Close() the server when
+// they're done with it.
+func NewServer(opts Options) *Server {
+	if opts.Seed == 0 {
+		opts.Seed = 1
+	}
+	if opts.Platform == "" {
+		opts.Platform = "NA1"
+	}
+	if opts.Summoners == 0 {
+		opts.Summoners = 50
+	}
+	if opts.Summoners < 10 {
+		opts.Summoners = 10 // every match needs ten distinct players
+	}
+	if opts.Matches == 0 {
+		opts.Matches = 200
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	s := &Server{
+		opts:     opts,
+		universe: newUniverse(opts),
+		faults:   rand.New(rand.NewSource(opts.Seed)),
+		byStatus: make(map[int]int),
+		byPath:   make(map[string]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// SeedAccount : Returns an account ID that has played at least one match, suitable for
+// starting a crawl.
+func (s *Server) SeedAccount() int64 {
+	s.world.RLock()
+	defer s.world.RUnlock()
+
+	for _, account := range s.universe.accounts {
+		if len(s.universe.history[account]) > 0 {
+			return account
+		}
+	}
+
+	return s.universe.accounts[0]
+}
+
+// Accounts : Returns all account ID's in the universe.
+func (s *Server) Accounts() []int64 {
+	s.world.RLock()
+	defer s.world.RUnlock()
+
+	return append([]int64{}, s.universe.accounts...)
+}
+
+// MatchIDs : Returns the game ID's of every match in the universe, most recent first.
+func (s *Server) MatchIDs() []int64 {
+	s.world.RLock()
+	defer s.world.RUnlock()
+
+	return append([]int64{}, s.universe.ids...)
+}
+
+// Play : Add `n` new matches between existing accounts, as if they were just played. Returns
+// the new game ID's, most recent first.
+func (s *Server) Play(n int) []int64 {
+	s.world.Lock()
+	defer s.world.Unlock()
+
+	return s.universe.play(n)
+}
+
+// Requests : Returns the total number of requests received.
+func (s *Server) Requests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.requests
+}
+
+// RequestsWithStatus : Returns the number of requests that received the specified status.
+func (s *Server) RequestsWithStatus(status int) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.byStatus[status]
+}
+
+// RequestsFor : Returns the number of requests received for a URL path, i.e.
+// "/lol/match/v3/matches/2500000000".
+func (s *Server) RequestsFor(path string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.byPath[path]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if s.opts.Latency > 0 {
+		time.Sleep(s.opts.Latency)
+	}
+
+	status := s.respond(w, r)
+
+	s.lock.Lock()
+	s.byStatus[status]++
+	s.byPath[r.URL.Path]++
+	s.lock.Unlock()
+}
+
+// respond : Write the response for a request and return its status code.
+func (s *Server) respond(w http.ResponseWriter, r *http.Request) int {
+	s.lock.Lock()
+	s.requests++
+	count := s.requests
+	failed := s.opts.ServerErrorRate > 0 && s.faults.Float64() < s.opts.ServerErrorRate
+	s.lock.Unlock()
+
+	if s.opts.APIKey != "" && r.Header.Get("X-Riot-Token") != s.opts.APIKey {
+		return writeStatus(w, http.StatusForbidden, "Forbidden")
+	}
+
+	if s.opts.RateLimitEvery > 0 && count%s.opts.RateLimitEvery == 0 {
+		w.Header().Set("X-Rate-Limit-Type", "service")
+		w.Header().Set("Retry-After", strconv.Itoa(s.opts.RetryAfter))
+
+		return writeStatus(w, http.StatusTooManyRequests, "Rate limit exceeded")
+	}
+
+	if failed {
+		return writeStatus(w, http.StatusServiceUnavailable, "Service unavailable")
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, matchlistPath):
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, matchlistPath), 10, 64)
+		if err != nil {
+			return writeStatus(w, http.StatusBadRequest, "Bad request")
+		}
+
+		return s.matchlist(w, r, id)
+	case strings.HasPrefix(r.URL.Path, matchPath):
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, matchPath), 10, 64)
+		if err != nil {
+			return writeStatus(w, http.StatusBadRequest, "Bad request")
+		}
+
+		s.world.RLock()
+		match, exists := s.universe.matches[id]
+		s.world.RUnlock()
+
+		if !exists {
+			return writeStatus(w, http.StatusNotFound, "Data not found")
+		}
+
+		return writeJSON(w, match)
+	case strings.HasPrefix(r.URL.Path, timelinePath):
+		id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, timelinePath), 10, 64)
+		if err != nil {
+			return writeStatus(w, http.StatusBadRequest, "Bad request")
+		}
+
+		s.world.RLock()
+		match, exists := s.universe.matches[id]
+		s.world.RUnlock()
+
+		if !exists {
+			return writeStatus(w, http.StatusNotFound, "Data not found")
+		}
+
+		return writeJSON(w, newTimeline(match))
+	}
+
+	return writeStatus(w, http.StatusNotFound, "Resource not found")
+}
+
+// matchlist : Serve an account's recent matches. Supports the beginIndex, endIndex, beginTime,
+// endTime, queue and season query parameters the same way Riot does.
+func (s *Server) matchlist(w http.ResponseWriter, r *http.Request, account int64) int {
+	s.world.RLock()
+	defer s.world.RUnlock()
+
+	history, exists := s.universe.history[account]
+	if !exists {
+		return writeStatus(w, http.StatusNotFound, "Data not found")
+	}
+
+	// Times are in epoch milliseconds and both ends are inclusive.
+	beginTime, err := strconv.ParseInt(r.URL.Query().Get("beginTime"), 10, 64)
+	if err != nil || (s.opts.IgnoreBeginTime && r.URL.Query().Get("endTime") == "") {
+		beginTime = math.MinInt64
+	}
+	endTime, err := strconv.ParseInt(r.URL.Query().Get("endTime"), 10, 64)
+	if err != nil {
+		endTime = math.MaxInt64
+	}
+
+	queues := intParams(r, "queue")
+	seasons := intParams(r, "season")
+
+	games := make([]int64, 0, len(history))
+	for _, id := range history {
+		m := s.universe.matches[id]
+
+		if m.GameCreation < beginTime || m.GameCreation > endTime {
+			continue
+		}
+		if (len(queues) > 0 && !queues[m.QueueID]) || (len(seasons) > 0 && !seasons[m.SeasonID]) {
+			continue
+		}
+
+		games = append(games, id)
+	}
+
+	// Riot responds with a 404 when nothing matches.
+	if len(games) == 0 {
+		return writeStatus(w, http.StatusNotFound, "Data not found")
+	}
+
+	begin, _ := strconv.Atoi(r.URL.Query().Get("beginIndex"))
+	end, err := strconv.Atoi(r.URL.Query().Get("endIndex"))
+	if err != nil || end > begin+maxMatchlist {
+		end = begin + maxMatchlist
+	}
+	if end > len(games) {
+		end = len(games)
+	}
+	if begin > end {
+		begin = end
+	}
+
+	list := apiMatchlist{
+		Matches:    make([]apiMatchReference, 0, end-begin),
+		StartIndex: begin,
+		EndIndex:   end,
+		TotalGames: len(games),
+	}
+
+	for _, id := range games[begin:end] {
+		list.Matches = append(list.Matches, s.universe.reference(s.universe.matches[id], account))
+	}
+
+	return writeJSON(w, list)
+}
+
+// intParams : Returns the set of values for a query parameter that can be repeated, i.e.
+// `queue=420&queue=440`. Values that aren't numbers are ignored.
+func intParams(r *http.Request, name string) map[int]bool {
+	values := make(map[int]bool)
+
+	for _, raw := range r.URL.Query()[name] {
+		if value, err := strconv.Atoi(raw); err == nil {
+			values[value] = true
+		}
+	}
+
+	return values
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) int {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return writeStatus(w, http.StatusInternalServerError, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(raw)
+
+	return http.StatusOK
+}
+
+// writeStatus : Write an error in the same format Riot uses.
+func writeStatus(w http.ResponseWriter, status int, message string) int {
+	body := struct {
+		Status struct {
+			StatusCode int    `json:"status_code"`
+			Message    string `json:"message"`
+		} `json:"status"`
+	}{}
+	body.Status.StatusCode = status
+	body.Status.Message = message
+
+	raw, _ := json.Marshal(body)
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(raw)
+
+	return status
+}
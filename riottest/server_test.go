@@ -0,0 +1,233 @@
+package riottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anyweez/matchgrab/structs"
+)
+
+func get(t *testing.T, s *Server, path string) (int, []byte) {
+	resp, err := http.Get(s.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, body
+}
+
+// TestDeterministic : Ensure the same seed always produces the same responses.
+func TestDeterministic(t *testing.T) {
+	now := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	a := NewServer(Options{Seed: 42, Now: now})
+	defer a.Close()
+	b := NewServer(Options{Seed: 42, Now: now})
+	defer b.Close()
+
+	path := fmt.Sprintf("/lol/match/v3/matches/%d", a.MatchIDs()[10])
+	_, first := get(t, a, path)
+	_, second := get(t, b, path)
+
+	if string(first) != string(second) {
+		t.Fail()
+	}
+}
+
+// TestMatchDecodes : Ensure matches have the same shape as Riot's and convert cleanly.
+func TestMatchDecodes(t *testing.T) {
+	s := NewServer(Options{})
+	defer s.Close()
+
+	id := s.MatchIDs()[0]
+	status, body := get(t, s, fmt.Sprintf("/lol/match/v3/matches/%d", id))
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+
+	var raw structs.APIMatch
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	match := structs.ToMatch(raw)
+	if int64(match.GameID) != id || len(match.Participants) != 10 {
+		t.Fail()
+	}
+
+	seen := make(map[structs.RiotID]bool)
+	for _, p := range match.Participants {
+		if seen[p.AccountID] {
+			t.Errorf("account %d appears twice", p.AccountID)
+		}
+		seen[p.AccountID] = true
+	}
+}
+
+// TestMatchlist : Ensure matchlists only include the account's matches and respect paging.
+func TestMatchlist(t *testing.T) {
+	s := NewServer(Options{Summoners: 10, Matches: 150})
+	defer s.Close()
+
+	// With ten summoners everyone plays in every match.
+	status, body := get(t, s, fmt.Sprintf("/lol/match/v3/matchlists/by-account/%d?beginIndex=120", s.SeedAccount()))
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+
+	list := apiMatchlist{}
+	json.Unmarshal(body, &list)
+
+	if list.TotalGames != 150 || list.StartIndex != 120 || list.EndIndex != 150 || len(list.Matches) != 30 {
+		t.Errorf("unexpected page: %d-%d of %d", list.StartIndex, list.EndIndex, list.TotalGames)
+	}
+
+	for i := 1; i < len(list.Matches); i++ {
+		if list.Matches[i].Timestamp > list.Matches[i-1].Timestamp {
+			t.Error("matches aren't most recent first")
+		}
+	}
+
+	status, _ = get(t, s, "/lol/match/v3/matchlists/by-account/1")
+	if status != http.StatusNotFound {
+		t.Fail()
+	}
+
+	_, body = get(t, s, fmt.Sprintf("/lol/match/v3/matchlists/by-account/%d?queue=420&queue=440", s.SeedAccount()))
+	list = apiMatchlist{}
+	json.Unmarshal(body, &list)
+
+	if len(list.Matches) == 0 || list.TotalGames == 150 {
+		t.Error("queue parameter was ignored")
+	}
+
+	for _, m := range list.Matches {
+		if m.Queue != 420 && m.Queue != 440 {
+			t.Errorf("match %d is from queue %d", m.GameID, m.Queue)
+		}
+	}
+}
+
+// TestPlay : Ensure new matches show up at the front of matchlists and can be filtered by time.
+func TestPlay(t *testing.T) {
+	s := NewServer(Options{Summoners: 10, Matches: 20})
+	defer s.Close()
+
+	oldest := s.MatchIDs()[0]
+	played := s.Play(3)
+
+	if len(played) != 3 || len(s.MatchIDs()) != 23 || s.MatchIDs()[0] != played[0] {
+		t.Fatal("played matches aren't the most recent")
+	}
+
+	// Everyone plays in every match, so the newest three should be all that's left.
+	created := s.universe.matches[oldest].GameCreation
+	path := fmt.Sprintf("/lol/match/v3/matchlists/by-account/%d?beginTime=%d", s.SeedAccount(), created+1)
+
+	status, body := get(t, s, path)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+
+	list := apiMatchlist{}
+	json.Unmarshal(body, &list)
+
+	if len(list.Matches) != 3 || list.Matches[0].GameID != played[0] {
+		t.Errorf("unexpected matches: %+v", list.Matches)
+	}
+
+	status, _ = get(t, s, path+"&endTime="+fmt.Sprint(created))
+	if status != http.StatusNotFound {
+		t.Error("empty time range should be a 404")
+	}
+}
+
+// TestInjectedFaults : Ensure rate limits and server errors are injected as configured.
+func TestInjectedFaults(t *testing.T) {
+	s := NewServer(Options{RateLimitEvery: 3, RetryAfter: 7})
+	defer s.Close()
+
+	path := fmt.Sprintf("/lol/match/v3/matches/%d", s.MatchIDs()[0])
+	for i := 0; i < 9; i++ {
+		resp, err := http.Get(s.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if (i+1)%3 == 0 && (resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "7") {
+			t.Errorf("request %d wasn't rate limited", i+1)
+		}
+	}
+
+	if s.RequestsWithStatus(http.StatusTooManyRequests) != 3 || s.RequestsFor(path) != 9 {
+		t.Fail()
+	}
+
+	failing := NewServer(Options{ServerErrorRate: 1})
+	defer failing.Close()
+
+	if status, _ := get(t, failing, path); status != http.StatusServiceUnavailable {
+		t.Fail()
+	}
+}
+
+// TestAPIKey : Ensure requests without the right key are rejected.
+func TestAPIKey(t *testing.T) {
+	s := NewServer(Options{APIKey: "abcde"})
+	defer s.Close()
+
+	status, _ := get(t, s, fmt.Sprintf("/lol/match/v3/matches/%d", s.MatchIDs()[0]))
+	if status != http.StatusForbidden {
+		t.Fail()
+	}
+}
+
+// TestTimeline : Ensure timelines decode and line up with the match they belong to.
+func TestTimeline(t *testing.T) {
+	s := NewServer(Options{})
+	defer s.Close()
+
+	id := s.MatchIDs()[0]
+	_, body := get(t, s, fmt.Sprintf("/lol/match/v3/matches/%d", id))
+
+	var match structs.APIMatch
+	if err := json.Unmarshal(body, &match); err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := get(t, s, fmt.Sprintf("/lol/match/v3/timelines/by-match/%d", id))
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+
+	var raw structs.APITimeline
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	tl := structs.ToTimeline("NA1", structs.RiotID(id), raw)
+	if len(tl.Frames) != match.GameDuration/60+1 {
+		t.Errorf("%d frames for a %d second match", len(tl.Frames), match.GameDuration)
+	}
+
+	for i, pf := range tl.Frames[0].ParticipantFrames {
+		if pf.ParticipantID != int32(i+1) {
+			t.Errorf("participant frame %d has ID %d", i, pf.ParticipantID)
+		}
+	}
+
+	if status, _ := get(t, s, "/lol/match/v3/timelines/by-match/1"); status != http.StatusNotFound {
+		t.Error("unknown match should be a 404")
+	}
+}
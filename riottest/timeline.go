@@ -0,0 +1,132 @@
+package riottest
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// The structs below mirror the JSON returned by Riot's timeline endpoint.
+
+type apiPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type apiParticipantFrame struct {
+	ParticipantID       int         `json:"participantId"`
+	Position            apiPosition `json:"position"`
+	CurrentGold         int         `json:"currentGold"`
+	TotalGold           int         `json:"totalGold"`
+	Level               int         `json:"level"`
+	XP                  int         `json:"xp"`
+	MinionsKilled       int         `json:"minionsKilled"`
+	JungleMinionsKilled int         `json:"jungleMinionsKilled"`
+}
+
+type apiEvent struct {
+	Type                    string       `json:"type"`
+	Timestamp               int64        `json:"timestamp"`
+	ParticipantID           int          `json:"participantId,omitempty"`
+	Position                *apiPosition `json:"position,omitempty"`
+	KillerID                int          `json:"killerId,omitempty"`
+	VictimID                int          `json:"victimId,omitempty"`
+	AssistingParticipantIDs []int        `json:"assistingParticipantIds,omitempty"`
+	ItemID                  int          `json:"itemId,omitempty"`
+	WardType                string       `json:"wardType,omitempty"`
+	CreatorID               int          `json:"creatorId,omitempty"`
+	TeamID                  int          `json:"teamId,omitempty"`
+	BuildingType            string       `json:"buildingType,omitempty"`
+	LaneType                string       `json:"laneType,omitempty"`
+	TowerType               string       `json:"towerType,omitempty"`
+}
+
+type apiFrame struct {
+	Timestamp         int64                          `json:"timestamp"`
+	ParticipantFrames map[string]apiParticipantFrame `json:"participantFrames"`
+	Events            []apiEvent                     `json:"events"`
+}
+
+type apiMatchTimeline struct {
+	Frames        []apiFrame `json:"frames"`
+	FrameInterval int64      `json:"frameInterval"`
+}
+
+const frameInterval = 60000 // milliseconds
+
+// newTimeline : Generate a timeline for a match with one frame per minute. Timelines are derived
+// from the game ID so they're the same every time they're requested.
+func newTimeline(m *apiMatch) apiMatchTimeline {
+	rng := rand.New(rand.NewSource(m.GameID))
+
+	tl := apiMatchTimeline{FrameInterval: frameInterval}
+	gold := make([]int, 11)
+	cs := make([]int, 11)
+
+	for minute := 0; minute <= m.GameDuration/60; minute++ {
+		frame := apiFrame{
+			Timestamp:         int64(minute * frameInterval),
+			ParticipantFrames: make(map[string]apiParticipantFrame, 10),
+			Events:            make([]apiEvent, 0),
+		}
+
+		for id := 1; id <= 10; id++ {
+			if minute > 0 {
+				gold[id] += 250 + rng.Intn(200)
+				cs[id] += rng.Intn(10)
+			}
+
+			frame.ParticipantFrames[strconv.Itoa(id)] = apiParticipantFrame{
+				ParticipantID: id,
+				Position:      apiPosition{X: rng.Intn(14820), Y: rng.Intn(14881)},
+				CurrentGold:   500 + gold[id]%1500,
+				TotalGold:     500 + gold[id],
+				Level:         1 + minute/2,
+				XP:            minute * 400,
+				MinionsKilled: cs[id],
+			}
+		}
+
+		timestamp := frame.Timestamp + int64(rng.Intn(frameInterval))
+
+		// Everyone buys their starting items right away; after that there's a kill and a ward
+		// every few minutes.
+		if minute == 0 {
+			for id := 1; id <= 10; id++ {
+				frame.Events = append(frame.Events, apiEvent{Type: "ITEM_PURCHASED", Timestamp: 1000, ParticipantID: id, ItemID: 1055})
+			}
+		} else if minute%3 == 0 {
+			killer := 1 + rng.Intn(10)
+			victim := 1 + (killer+4+rng.Intn(5))%10
+
+			frame.Events = append(frame.Events, apiEvent{
+				Type:      "CHAMPION_KILL",
+				Timestamp: timestamp,
+				Position:  &apiPosition{X: rng.Intn(14820), Y: rng.Intn(14881)},
+				KillerID:  killer,
+				VictimID:  victim,
+			}, apiEvent{
+				Type:      "WARD_PLACED",
+				Timestamp: timestamp,
+				WardType:  "YELLOW_TRINKET",
+				CreatorID: victim,
+			})
+		}
+
+		if minute == m.GameDuration/60 {
+			frame.Events = append(frame.Events, apiEvent{
+				Type:         "BUILDING_KILL",
+				Timestamp:    timestamp,
+				Position:     &apiPosition{X: 1748, Y: 2270},
+				KillerID:     1,
+				TeamID:       200,
+				BuildingType: "TOWER_BUILDING",
+				LaneType:     "MID_LANE",
+				TowerType:    "NEXUS_TURRET",
+			})
+		}
+
+		tl.Frames = append(tl.Frames, frame)
+	}
+
+	return tl
+}
@@ -0,0 +1,351 @@
+package riottest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// The structs below mirror the JSON returned by Riot's match-v3 endpoints (see the files in
+// sample/ for real responses). Only the fields matchgrab reads are generated.
+
+type apiMatch struct {
+	GameID       int64  `json:"gameId"`
+	PlatformID   string `json:"platformId"`
+	GameCreation int64  `json:"gameCreation"`
+	GameDuration int    `json:"gameDuration"`
+	QueueID      int    `json:"queueId"`
+	MapID        int    `json:"mapId"`
+	SeasonID     int    `json:"seasonId"`
+	GameVersion  string `json:"gameVersion"`
+	GameMode     string `json:"gameMode"`
+	GameType     string `json:"gameType"`
+
+	Teams                 []apiTeam                `json:"teams"`
+	Participants          []apiParticipant         `json:"participants"`
+	ParticipantIdentities []apiParticipantIdentity `json:"participantIdentities"`
+}
+
+type apiTeam struct {
+	TeamID          int      `json:"teamId"`
+	Win             string   `json:"win"`
+	FirstBlood      bool     `json:"firstBlood"`
+	FirstTower      bool     `json:"firstTower"`
+	FirstInhibitor  bool     `json:"firstInhibitor"`
+	FirstBaron      bool     `json:"firstBaron"`
+	FirstDragon     bool     `json:"firstDragon"`
+	FirstRiftHerald bool     `json:"firstRiftHerald"`
+	TowerKills      int      `json:"towerKills"`
+	InhibitorKills  int      `json:"inhibitorKills"`
+	BaronKills      int      `json:"baronKills"`
+	DragonKills     int      `json:"dragonKills"`
+	RiftHeraldKills int      `json:"riftHeraldKills"`
+	Bans            []apiBan `json:"bans"`
+}
+
+type apiBan struct {
+	ChampionID int `json:"championId"`
+	PickTurn   int `json:"pickTurn"`
+}
+
+type apiParticipant struct {
+	ParticipantID             int    `json:"participantId"`
+	TeamID                    int    `json:"teamId"`
+	ChampionID                int    `json:"championId"`
+	Spell1ID                  int    `json:"spell1Id"`
+	Spell2ID                  int    `json:"spell2Id"`
+	HighestAchievedSeasonTier string `json:"highestAchievedSeasonTier"`
+
+	Stats    apiStats    `json:"stats"`
+	Timeline apiTimeline `json:"timeline"`
+}
+
+type apiStats struct {
+	ParticipantID      int  `json:"participantId"`
+	Win                bool `json:"win"`
+	Item0              int  `json:"item0"`
+	Item1              int  `json:"item1"`
+	Item2              int  `json:"item2"`
+	Item3              int  `json:"item3"`
+	Item4              int  `json:"item4"`
+	Item5              int  `json:"item5"`
+	Item6              int  `json:"item6"`
+	Kills              int  `json:"kills"`
+	Deaths             int  `json:"deaths"`
+	Assists            int  `json:"assists"`
+	GoldEarned         int  `json:"goldEarned"`
+	TotalMinionsKilled int  `json:"totalMinionsKilled"`
+	ChampLevel         int  `json:"champLevel"`
+	PerkPrimaryStyle   int  `json:"perkPrimaryStyle"`
+	PerkSubStyle       int  `json:"perkSubStyle"`
+	Perk0              int  `json:"perk0"`
+	Perk1              int  `json:"perk1"`
+	Perk2              int  `json:"perk2"`
+	Perk3              int  `json:"perk3"`
+	Perk4              int  `json:"perk4"`
+	Perk5              int  `json:"perk5"`
+}
+
+type apiTimeline struct {
+	ParticipantID int    `json:"participantId"`
+	Role          string `json:"role"`
+	Lane          string `json:"lane"`
+}
+
+type apiParticipantIdentity struct {
+	ParticipantID int       `json:"participantId"`
+	Player        apiPlayer `json:"player"`
+}
+
+type apiPlayer struct {
+	PlatformID        string `json:"platformId"`
+	AccountID         int64  `json:"accountId"`
+	SummonerName      string `json:"summonerName"`
+	SummonerID        int64  `json:"summonerId"`
+	CurrentPlatformID string `json:"currentPlatformId"`
+	CurrentAccountID  int64  `json:"currentAccountId"`
+	MatchHistoryURI   string `json:"matchHistoryUri"`
+	ProfileIcon       int    `json:"profileIcon"`
+}
+
+type apiMatchReference struct {
+	PlatformID string `json:"platformId"`
+	GameID     int64  `json:"gameId"`
+	Champion   int    `json:"champion"`
+	Queue      int    `json:"queue"`
+	Season     int    `json:"season"`
+	Timestamp  int64  `json:"timestamp"`
+	Role       string `json:"role"`
+	Lane       string `json:"lane"`
+}
+
+type apiMatchlist struct {
+	Matches    []apiMatchReference `json:"matches"`
+	StartIndex int                 `json:"startIndex"`
+	EndIndex   int                 `json:"endIndex"`
+	TotalGames int                 `json:"totalGames"`
+}
+
+// Values that synthetic matches are built from.
+var (
+	champions = []int{1, 3, 5, 19, 23, 50, 63, 81, 96, 122, 164, 202, 222, 236, 412, 429, 432, 497, 498, 516}
+	queues    = []int{420, 440, 400, 430}
+	positions = []struct{ lane, role string }{
+		{"TOP", "SOLO"}, {"JUNGLE", "NONE"}, {"MIDDLE", "SOLO"}, {"BOTTOM", "DUO_CARRY"}, {"BOTTOM", "DUO_SUPPORT"},
+	}
+	tiers = []string{"UNRANKED", "BRONZE", "SILVER", "GOLD", "PLATINUM", "DIAMOND"}
+	items = []int{1001, 1028, 1052, 1056, 2031, 3006, 3020, 3089, 3157, 3165, 3340, 3348}
+
+	// Rune pages for each position: primary style, secondary style, and six perks.
+	runePages = [][8]int{
+		{8000, 8400, 8010, 9111, 9104, 8299, 8444, 8242},
+		{8100, 8000, 8128, 8126, 8138, 8135, 9111, 8014},
+		{8100, 8200, 8112, 8139, 8138, 8105, 8210, 8237},
+		{8000, 8100, 8008, 9111, 9104, 8014, 8139, 8135},
+		{8400, 8300, 8439, 8446, 8429, 8451, 8345, 8347},
+	}
+)
+
+const (
+	firstAccountID = 200000000
+	firstGameID    = 2500000000
+	matchSpacing   = 20 * time.Minute // time between consecutive matches in the universe
+)
+
+// universe : A deterministic set of summoners and the matches they played in. The same seed
+// always produces the same universe.
+type universe struct {
+	platform string
+	matches  map[int64]*apiMatch
+	history  map[int64][]int64 // account ID => game ID's, most recent first
+	accounts []int64
+	ids      []int64 // all game ID's, most recent first
+
+	rng    *rand.Rand // kept so that play() is deterministic as well
+	newest time.Time  // creation time of the most recent match
+}
+
+func newUniverse(opts Options) *universe {
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	u := &universe{
+		platform: opts.Platform,
+		matches:  make(map[int64]*apiMatch, opts.Matches),
+		history:  make(map[int64][]int64, opts.Summoners),
+		accounts: make([]int64, opts.Summoners),
+		ids:      make([]int64, 0, opts.Matches),
+		rng:      rng,
+		newest:   opts.Now,
+	}
+
+	for i := range u.accounts {
+		u.accounts[i] = int64(firstAccountID + i)
+	}
+
+	for i := 0; i < opts.Matches; i++ {
+		created := opts.Now.Add(-time.Duration(i) * matchSpacing)
+		m := u.newMatch(rng, int64(firstGameID+i), created)
+
+		u.matches[m.GameID] = m
+		u.ids = append(u.ids, m.GameID)
+		for _, pi := range m.ParticipantIdentities {
+			u.history[pi.Player.AccountID] = append(u.history[pi.Player.AccountID], m.GameID)
+		}
+	}
+
+	// Most recent matches first, same as Riot.
+	for account := range u.history {
+		games := u.history[account]
+		sort.Slice(games, func(i, j int) bool {
+			return u.matches[games[i]].GameCreation > u.matches[games[j]].GameCreation
+		})
+	}
+
+	return u
+}
+
+// play : Add `n` matches that were created after every existing match and return their game
+// ID's, most recent first.
+func (u *universe) play(n int) []int64 {
+	played := make([]int64, 0, n)
+
+	for i := 0; i < n; i++ {
+		u.newest = u.newest.Add(matchSpacing)
+		m := u.newMatch(u.rng, int64(firstGameID+len(u.matches)), u.newest)
+
+		u.matches[m.GameID] = m
+		u.ids = append([]int64{m.GameID}, u.ids...)
+		for _, pi := range m.ParticipantIdentities {
+			account := pi.Player.AccountID
+			u.history[account] = append([]int64{m.GameID}, u.history[account]...)
+		}
+
+		played = append([]int64{m.GameID}, played...)
+	}
+
+	return played
+}
+
+func (u *universe) newMatch(rng *rand.Rand, gameID int64, created time.Time) *apiMatch {
+	m := &apiMatch{
+		GameID:       gameID,
+		PlatformID:   u.platform,
+		GameCreation: created.UnixNano() / int64(time.Millisecond),
+		GameDuration: 1200 + rng.Intn(1200),
+		QueueID:      queues[rng.Intn(len(queues))],
+		MapID:        11,
+		SeasonID:     9,
+		GameVersion:  fmt.Sprintf("7.%d.195.3579", 10+rng.Intn(10)),
+		GameMode:     "CLASSIC",
+		GameType:     "MATCHED_GAME",
+	}
+
+	winner := 100 + 100*rng.Intn(2)
+	picks := rng.Perm(len(champions))
+	players := rng.Perm(len(u.accounts))
+
+	for t, teamID := range []int{100, 200} {
+		team := apiTeam{
+			TeamID:      teamID,
+			Win:         "Fail",
+			TowerKills:  rng.Intn(12),
+			DragonKills: rng.Intn(5),
+			BaronKills:  rng.Intn(3),
+		}
+
+		if teamID == winner {
+			team.Win = "Win"
+			team.FirstTower = true
+		}
+
+		// Bans come from the end of the permutation so they never overlap with picks.
+		for b := 0; b < 3; b++ {
+			team.Bans = append(team.Bans, apiBan{
+				ChampionID: champions[picks[len(picks)-1-(t*3+b)]],
+				PickTurn:   t + 1 + b*2,
+			})
+		}
+
+		m.Teams = append(m.Teams, team)
+	}
+
+	for i := 0; i < 10; i++ {
+		teamID := 100 + 100*(i/5)
+		account := u.accounts[players[i%len(players)]]
+		position := positions[i%5]
+		page := runePages[i%5]
+
+		m.Participants = append(m.Participants, apiParticipant{
+			ParticipantID:             i + 1,
+			TeamID:                    teamID,
+			ChampionID:                champions[picks[i]],
+			Spell1ID:                  4,
+			Spell2ID:                  []int{11, 12, 14, 7, 3}[i%5],
+			HighestAchievedSeasonTier: tiers[rng.Intn(len(tiers))],
+			Stats: apiStats{
+				ParticipantID:      i + 1,
+				Win:                teamID == winner,
+				Item0:              items[rng.Intn(len(items))],
+				Item1:              items[rng.Intn(len(items))],
+				Item6:              3340,
+				Kills:              rng.Intn(15),
+				Deaths:             rng.Intn(12),
+				Assists:            rng.Intn(20),
+				GoldEarned:         6000 + rng.Intn(10000),
+				TotalMinionsKilled: rng.Intn(250),
+				ChampLevel:         10 + rng.Intn(8),
+				PerkPrimaryStyle:   page[0],
+				PerkSubStyle:       page[1],
+				Perk0:              page[2],
+				Perk1:              page[3],
+				Perk2:              page[4],
+				Perk3:              page[5],
+				Perk4:              page[6],
+				Perk5:              page[7],
+			},
+			Timeline: apiTimeline{
+				ParticipantID: i + 1,
+				Role:          position.role,
+				Lane:          position.lane,
+			},
+		})
+
+		m.ParticipantIdentities = append(m.ParticipantIdentities, apiParticipantIdentity{
+			ParticipantID: i + 1,
+			Player: apiPlayer{
+				PlatformID:        u.platform,
+				AccountID:         account,
+				SummonerName:      fmt.Sprintf("Summoner%d", account),
+				SummonerID:        account - firstAccountID + 30000000,
+				CurrentPlatformID: u.platform,
+				CurrentAccountID:  account,
+				MatchHistoryURI:   fmt.Sprintf("/v1/stats/player_history/%s/%d", u.platform, account),
+				ProfileIcon:       rng.Intn(30),
+			},
+		})
+	}
+
+	return m
+}
+
+// reference : Build the matchlist entry for an account's appearance in a match.
+func (u *universe) reference(m *apiMatch, account int64) apiMatchReference {
+	ref := apiMatchReference{
+		PlatformID: m.PlatformID,
+		GameID:     m.GameID,
+		Queue:      m.QueueID,
+		Season:     m.SeasonID,
+		Timestamp:  m.GameCreation,
+	}
+
+	for i, pi := range m.ParticipantIdentities {
+		if pi.Player.AccountID == account {
+			ref.Champion = m.Participants[i].ChampionID
+			ref.Role = m.Participants[i].Timeline.Role
+			ref.Lane = m.Participants[i].Timeline.Lane
+		}
+	}
+
+	return ref
+}
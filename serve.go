@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/anyweez/matchgrab/config"
+	"github.com/anyweez/matchgrab/server"
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// serve : Serve stored matches over HTTP; see the server package for endpoints. The live store
+// can't be opened while it's being crawled, so use -snapshot (or `grab crawl -serve`) then.
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	snapshot := flags.Bool("snapshot", false, "serve the snapshot copy instead of the live store")
+	flags.Parse(args)
+
+	opts := server.Options{Platform: config.Config.Platforms[0]}
+
+	if *snapshot {
+		opts.Location = config.Config.MatchStoreLocation + structs.SnapshotSuffix
+	} else {
+		var err error
+		store, err = structs.OpenMatchStore(config.Config.MatchStoreLocation)
+		if err != nil {
+			fmt.Println("Couldn't open match store (is it being crawled? try -snapshot): " + err.Error())
+			os.Exit(1)
+		}
+		defer store.Close()
+
+		opts.Store = store
+	}
+
+	fmt.Printf("Serving matches on %s\n", *addr)
+
+	if err := http.ListenAndServe(*addr, server.New(opts)); err != nil {
+		fmt.Println("Error serving matches: " + err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,366 @@
+// Package server provides a read-only HTTP interface to a match store. It serves the same
+// endpoints as preview.py, with pagination and filters, using the store's indexes instead of
+// scanning the whole database:
+//
+//	GET /match/{id}      a single match
+//	GET /account/{id}    an account's matches, newest first
+//	GET /accounts        every account with a stored match, with its summoner name
+//
+// All endpoints accept a `platform` parameter (default Options.Platform). The list endpoints
+// accept `offset` and `limit`; see the handlers for the filters each one supports.
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/anyweez/matchgrab/structs"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Lists return this many results unless a smaller `limit` is requested.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Options : Where a Server reads matches from. Set either Store or Location.
+type Options struct {
+	// Serve from an open store, i.e. the one being crawled.
+	Store *structs.MatchStore
+
+	// Otherwise, open the snapshot at Location read-only. It's reopened whenever Location (a
+	// symlink; see MatchStore.Snapshot()) is moved to a new generation.
+	Location string
+
+	// Platform used by requests that don't specify one (default structs.LegacyPlatform).
+	Platform string
+}
+
+// Server : Serves matches from a store. Implements http.Handler.
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+
+	// Requests hold lock for reading while they use the snapshot, and it's held for writing while
+	// the snapshot is reopened.
+	lock     sync.RWMutex
+	snapshot *structs.MatchStore
+	target   string // generation the snapshot was opened from
+}
+
+// New : Create a server. Panics if neither a store nor a location is provided.
+func New(opts Options) *Server {
+	if opts.Store == nil && opts.Location == "" {
+		panic("server: Options.Store or Options.Location is required")
+	}
+	if opts.Platform == "" {
+		opts.Platform = structs.LegacyPlatform
+	}
+
+	s := &Server{
+		opts: opts,
+		mux:  http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/match/", s.withStore(s.match))
+	s.mux.HandleFunc("/account/", s.withStore(s.account))
+	s.mux.HandleFunc("/accounts", s.withStore(s.accounts))
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type handler func(w http.ResponseWriter, r *http.Request, store *structs.MatchStore, platform string)
+
+// withStore : Provide a handler with the store to read from and the requested platform.
+func (s *Server) withStore(fn handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		platform := strings.ToUpper(r.URL.Query().Get("platform"))
+		if platform == "" {
+			platform = s.opts.Platform
+		}
+
+		if s.opts.Store != nil {
+			fn(w, r, s.opts.Store, platform)
+			return
+		}
+
+		store, err := s.openSnapshot()
+		if err != nil {
+			writeError(w, http.StatusServiceUnavailable, "Couldn't open match store: "+err.Error())
+			return
+		}
+		defer s.lock.RUnlock()
+
+		fn(w, r, store, platform)
+	}
+}
+
+// openSnapshot : Returns the snapshot at Location, reopening it if Location now points to a
+// different generation. On success the caller holds s.lock for reading and must release it.
+func (s *Server) openSnapshot() (*structs.MatchStore, error) {
+	target, err := filepath.EvalSymlinks(s.opts.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	if s.snapshot != nil && s.target == target {
+		return s.snapshot, nil
+	}
+	s.lock.RUnlock()
+
+	// Waits for requests using the old generation to finish before closing it.
+	s.lock.Lock()
+	if s.snapshot == nil || s.target != target {
+		snapshot, err := structs.OpenReadOnly(target)
+		if err != nil {
+			s.lock.Unlock()
+			return nil, err
+		}
+
+		if s.snapshot != nil {
+			s.snapshot.Close()
+		}
+		s.snapshot, s.target = snapshot, target
+	}
+	s.lock.Unlock()
+
+	// Another request may have reopened it again in between, which is fine.
+	s.lock.RLock()
+	if s.snapshot == nil {
+		s.lock.RUnlock()
+		return nil, structs.ErrStoreClosed
+	}
+
+	return s.snapshot, nil
+}
+
+// Close : Close the snapshot if one is open. Stores passed in Options.Store aren't closed.
+func (s *Server) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.snapshot != nil {
+		s.snapshot.Close()
+		s.snapshot = nil
+	}
+}
+
+// match : GET /match/{id}
+func (s *Server) match(w http.ResponseWriter, r *http.Request, store *structs.MatchStore, platform string) {
+	id, ok := pathID(w, r, "/match/")
+	if !ok {
+		return
+	}
+
+	match, err := store.Get(platform, id)
+	if err == leveldb.ErrNotFound {
+		writeError(w, http.StatusNotFound, "Match not found")
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, match)
+}
+
+// MatchPage : Response for /account/{id}.
+type MatchPage struct {
+	Total   int              `json:"total"` // matches that passed the filters
+	Offset  int              `json:"offset"`
+	Limit   int              `json:"limit"`
+	Matches []*structs.Match `json:"matches"`
+}
+
+// account : GET /account/{id}. Filters: `queue` and `season` (repeatable), `champion` (matches
+// where the account played that champion), and `begin`/`end` (creation time in epoch
+// milliseconds, inclusive).
+func (s *Server) account(w http.ResponseWriter, r *http.Request, store *structs.MatchStore, platform string) {
+	account, ok := pathID(w, r, "/account/")
+	if !ok {
+		return
+	}
+
+	offset, limit := paging(r)
+	queues := intParams(r, "queue")
+	seasons := intParams(r, "season")
+	champion, _ := strconv.ParseInt(r.URL.Query().Get("champion"), 10, 64)
+	begin, _ := strconv.ParseInt(r.URL.Query().Get("begin"), 10, 64)
+	end, _ := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+
+	games, err := store.ByAccount(platform, account)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	page := MatchPage{Offset: offset, Limit: limit, Matches: make([]*structs.Match, 0, limit)}
+
+	// Newer matches have higher ID's.
+	for i := len(games) - 1; i >= 0; i-- {
+		match, err := store.Get(platform, games[i])
+		if err != nil {
+			continue
+		}
+
+		if (len(queues) > 0 && !queues[match.QueueID]) || (len(seasons) > 0 && !seasons[match.SeasonID]) {
+			continue
+		}
+		if (begin > 0 && match.GameCreation < begin) || (end > 0 && match.GameCreation > end) {
+			continue
+		}
+		if champion > 0 && !playedChampion(match, account, structs.RiotID(champion)) {
+			continue
+		}
+
+		if page.Total >= offset && len(page.Matches) < limit {
+			page.Matches = append(page.Matches, match)
+		}
+		page.Total++
+	}
+
+	writeJSON(w, page)
+}
+
+// Account : An entry in the /accounts response.
+type Account struct {
+	AccountID    structs.RiotID `json:"accountId"`
+	SummonerName string         `json:"summonerName"` // as of the account's newest match
+	Games        int            `json:"games"`
+}
+
+// AccountPage : Response for /accounts.
+type AccountPage struct {
+	Total    int       `json:"total"` // accounts that passed the filters
+	Offset   int       `json:"offset"`
+	Limit    int       `json:"limit"`
+	Accounts []Account `json:"accounts"`
+}
+
+// accounts : GET /accounts. Filters: `min_games` (only accounts with at least this many stored
+// matches).
+func (s *Server) accounts(w http.ResponseWriter, r *http.Request, store *structs.MatchStore, platform string) {
+	offset, limit := paging(r)
+	minGames, _ := strconv.Atoi(r.URL.Query().Get("min_games"))
+
+	page := AccountPage{Offset: offset, Limit: limit, Accounts: make([]Account, 0, limit)}
+
+	err := store.Accounts(platform, func(account structs.RiotID, games []structs.RiotID) {
+		if len(games) < minGames {
+			return
+		}
+
+		// Only look up names for the accounts that are returned.
+		if page.Total >= offset && len(page.Accounts) < limit {
+			entry := Account{AccountID: account, Games: len(games)}
+
+			if match, err := store.Get(platform, games[len(games)-1]); err == nil {
+				for _, p := range match.Participants {
+					if p.AccountID == account {
+						entry.SummonerName = p.SummonerName
+					}
+				}
+			}
+
+			page.Accounts = append(page.Accounts, entry)
+		}
+		page.Total++
+	})
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, page)
+}
+
+func playedChampion(m *structs.Match, account structs.RiotID, champion structs.RiotID) bool {
+	for _, p := range m.Participants {
+		if p.AccountID == account && p.ChampionID == champion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathID : Parse the ID at the end of the path. Writes a 400 and returns false if it isn't a
+// number.
+func pathID(w http.ResponseWriter, r *http.Request, prefix string) (structs.RiotID, bool) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, prefix), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid ID")
+		return 0, false
+	}
+
+	return structs.RiotID(id), true
+}
+
+// paging : Returns the requested offset and limit, within bounds.
+func paging(r *http.Request) (int, int) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	return offset, limit
+}
+
+// intParams : Returns the set of values for a query parameter that can be repeated, i.e.
+// `queue=420&queue=440`. Values that aren't numbers are ignored.
+func intParams(r *http.Request, name string) map[int]bool {
+	values := make(map[int]bool)
+
+	for _, raw := range r.URL.Query()[name] {
+		if value, err := strconv.Atoi(raw); err == nil {
+			values[value] = true
+		}
+	}
+
+	return values
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.Write(raw)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	raw, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{message})
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(raw)
+}
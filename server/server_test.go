@@ -0,0 +1,204 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/anyweez/matchgrab/structs"
+)
+
+// testServer : Serve a store with ten NA1 matches between accounts 1-4 and one EUW1 match.
+// Account 1 plays champion 11 in odd matches and 10 in even ones. Matches are in queue 420
+// unless their ID is divisible by 3.
+func testServer(t *testing.T) (*httptest.Server, func()) {
+	dir, err := ioutil.TempDir("", "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := structs.NewMatchStore(dir + "/matches.db")
+
+	for i := 1; i <= 10; i++ {
+		queue := 420
+		if i%3 == 0 {
+			queue = 440
+		}
+
+		store.Add(structs.Match{
+			GameID:       structs.RiotID(i),
+			PlatformID:   "NA1",
+			QueueID:      queue,
+			GameCreation: int64(i * 1000),
+			Participants: []structs.Participant{
+				{AccountID: 1, SummonerName: fmt.Sprintf("one-%d", i), ChampionID: structs.RiotID(10 + i%2)},
+				{AccountID: 2, SummonerName: "two", ChampionID: 20},
+				{AccountID: structs.RiotID(3 + i%2), SummonerName: "other", ChampionID: 30},
+			},
+		})
+	}
+
+	store.Add(structs.Match{
+		GameID:       structs.RiotID(1),
+		PlatformID:   "EUW1",
+		Participants: []structs.Participant{{AccountID: 1, SummonerName: "euw"}},
+	})
+
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(New(Options{Store: store, Platform: "NA1"}))
+
+	return srv, func() {
+		srv.Close()
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func get(t *testing.T, srv *httptest.Server, path string, v interface{}) int {
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if v != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return resp.StatusCode
+}
+
+// TestMatch : Ensure single matches are served from the requested platform.
+func TestMatch(t *testing.T) {
+	srv, cleanup := testServer(t)
+	defer cleanup()
+
+	var match structs.Match
+	if status := get(t, srv, "/match/4", &match); status != http.StatusOK || match.GameID != 4 || match.PlatformID != "NA1" {
+		t.Errorf("unexpected response %d: %+v", status, match)
+	}
+
+	if get(t, srv, "/match/1?platform=euw1", &match); match.PlatformID != "EUW1" {
+		t.Errorf("served %s match for EUW1", match.PlatformID)
+	}
+
+	if status := get(t, srv, "/match/99", nil); status != http.StatusNotFound {
+		t.Errorf("missing match returned %d", status)
+	}
+	if status := get(t, srv, "/match/abc", nil); status != http.StatusBadRequest {
+		t.Errorf("invalid ID returned %d", status)
+	}
+}
+
+// TestAccount : Ensure an account's matches are paged and filtered, newest first.
+func TestAccount(t *testing.T) {
+	srv, cleanup := testServer(t)
+	defer cleanup()
+
+	var page MatchPage
+	get(t, srv, "/account/1?limit=4&offset=2", &page)
+
+	if page.Total != 10 || len(page.Matches) != 4 || page.Matches[0].GameID != 8 || page.Matches[3].GameID != 5 {
+		t.Errorf("unexpected page: total %d, %d matches", page.Total, len(page.Matches))
+	}
+
+	get(t, srv, "/account/1?queue=440", &page)
+	if page.Total != 3 {
+		t.Errorf("found %d queue 440 matches", page.Total)
+	}
+
+	get(t, srv, "/account/1?champion=11&begin=3000&end=8000", &page)
+	if page.Total != 3 || page.Matches[0].GameID != 7 {
+		t.Errorf("found %d champion 11 matches", page.Total)
+	}
+
+	get(t, srv, "/account/4", &page)
+	if page.Total != 5 {
+		t.Errorf("found %d matches for account 4", page.Total)
+	}
+}
+
+// TestAccounts : Ensure accounts are listed with their newest summoner name.
+func TestAccounts(t *testing.T) {
+	srv, cleanup := testServer(t)
+	defer cleanup()
+
+	var page AccountPage
+	get(t, srv, "/accounts", &page)
+
+	if page.Total != 4 || len(page.Accounts) != 4 {
+		t.Fatalf("found %d accounts", page.Total)
+	}
+
+	if first := page.Accounts[0]; first.AccountID != 1 || first.Games != 10 || first.SummonerName != "one-10" {
+		t.Errorf("unexpected account: %+v", first)
+	}
+
+	get(t, srv, "/accounts?min_games=6&limit=1", &page)
+	if page.Total != 2 || len(page.Accounts) != 1 {
+		t.Errorf("found %d accounts with 6 or more games", page.Total)
+	}
+
+	get(t, srv, "/accounts?platform=EUW1", &page)
+	if page.Total != 1 || page.Accounts[0].SummonerName != "euw" {
+		t.Errorf("unexpected EUW1 accounts: %+v", page.Accounts)
+	}
+}
+
+// TestLocation : Ensure a snapshot is kept open between requests and reopened once it's replaced.
+func TestLocation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := structs.NewMatchStore(dir + "/matches.db")
+	defer store.Close()
+
+	store.Add(structs.Match{GameID: structs.RiotID(1), PlatformID: "NA1"})
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	server := New(Options{Location: dir + "/matches.db" + structs.SnapshotSuffix})
+	defer server.Close()
+
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	for i := 0; i < 3; i++ {
+		if status := get(t, srv, "/match/1", nil); status != http.StatusOK {
+			t.Fatalf("request %d returned %d", i, status)
+		}
+	}
+
+	first := server.snapshot
+	if status := get(t, srv, "/match/2", nil); status != http.StatusNotFound || server.snapshot != first {
+		t.Errorf("match 2 returned %d", status)
+	}
+
+	store.Add(structs.Match{GameID: structs.RiotID(2), PlatformID: "NA1"})
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	if status := get(t, srv, "/match/2", nil); status != http.StatusOK || server.snapshot == first {
+		t.Errorf("match 2 returned %d after the snapshot was replaced", status)
+	}
+}
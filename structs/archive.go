@@ -0,0 +1,122 @@
+package structs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const archivePrefix = "raw:"
+
+// ArchiveKey : Returns the LevelDB key for a match's raw API response. Like timelines, the archive
+// has its own keyspace so it's never returned by Each().
+func ArchiveKey(platform string, id RiotID) []byte {
+	return append([]byte(archivePrefix+platform+":"), id.Bytes()...)
+}
+
+// Archive : Store the raw API response for a match, gzipped. Archived responses can be converted
+// again later with Reprocess() to pick up fields that ToMatch() didn't know about when the match
+// was first stored.
+func (ms *MatchStore) Archive(platform string, id RiotID, body []byte) error {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	return ms.db.Put(ArchiveKey(platform, id), buf.Bytes(), nil)
+}
+
+// Raw : Retrieve the archived API response for a match. Returns leveldb.ErrNotFound if it
+// wasn't archived.
+func (ms *MatchStore) Raw(platform string, id RiotID) ([]byte, error) {
+	compressed, err := ms.db.Get(ArchiveKey(platform, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unzip(compressed)
+}
+
+// EachRaw : Iterate over every archived API response, across all platforms.
+func (ms *MatchStore) EachRaw(fn func(platform string, id RiotID, body []byte)) error {
+	iter := ms.db.NewIterator(util.BytesPrefix([]byte(archivePrefix)), nil)
+	defer iter.Release()
+
+	idLen := len(RiotID(0).Bytes())
+
+	for iter.Next() {
+		// Keys are "raw:<platform>:" followed by the GameID.
+		key := iter.Key()
+		if len(key) < len(archivePrefix)+idLen+1 {
+			continue
+		}
+
+		platform := string(key[len(archivePrefix) : len(key)-idLen-1])
+		id := RiotID(binary.BigEndian.Uint64(key[len(key)-idLen:]))
+
+		body, err := unzip(iter.Value())
+		if err != nil {
+			return err
+		}
+
+		fn(platform, id, body)
+	}
+
+	return iter.Error()
+}
+
+// Reprocess : Rebuild every archived match with the current version of ToMatch() and replace
+// the stored records. Returns the number of matches rebuilt; responses that no longer decode are
+// skipped.
+func (ms *MatchStore) Reprocess() (int, error) {
+	count := 0
+	var writeErr error
+
+	err := ms.EachRaw(func(platform string, id RiotID, body []byte) {
+		if writeErr != nil {
+			return
+		}
+
+		var raw APIMatch
+		if err := json.Unmarshal(body, &raw); err != nil || raw.GameID == 0 {
+			return
+		}
+
+		match := ToMatch(raw)
+		match.PlatformID = platform
+
+		writeErr = ms.putMatches([]Match{match}, func(batch *leveldb.Batch) error {
+			return ms.db.Write(batch, nil)
+		})
+
+		if writeErr == nil {
+			count++
+		}
+	})
+
+	if err == nil {
+		err = writeErr
+	}
+
+	return count, err
+}
+
+func unzip(compressed []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return ioutil.ReadAll(zr)
+}
@@ -0,0 +1,78 @@
+package structs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Make sure archived responses can be read back and rebuild matches stored in an older format.
+func TestReprocess(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(dir + SnapshotSuffix)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	body, err := ioutil.ReadFile("../sample/2546243495.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := RiotID(2546243495)
+	if _, err := store.Raw("NA1", id); err != leveldb.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := store.Archive("NA1", id, body); err != nil {
+		t.Fatal(err)
+	}
+
+	if raw, err := store.Raw("NA1", id); err != nil || !bytes.Equal(raw, body) {
+		t.Fatalf("archived response changed: %v", err)
+	}
+
+	// Store the match the way an older version would have, without the newer fields.
+	var old Match
+	for _, sample := range rawSamples() {
+		if sample.GameID == id {
+			old = ToMatch(sample)
+		}
+	}
+	old.PlatformID = "NA1"
+	old.GameVersion = ""
+	old.QueueID = 0
+	old.Teams = nil
+
+	if err := store.db.Put(old.Key(), old.Bytes(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := store.Reprocess()
+	if err != nil || count != 1 {
+		t.Fatalf("rebuilt %d matches: %v", count, err)
+	}
+
+	match, err := store.Get("NA1", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if match.GameVersion == "" || match.QueueID == 0 || len(match.Teams) != 2 || match.PlatformID != "NA1" {
+		t.Errorf("match wasn't rebuilt: %+v", match)
+	}
+
+	matches := 0
+	store.Each(func(m *Match) {
+		matches++
+	})
+
+	if matches != 1 {
+		t.Errorf("found %d matches; the archive shouldn't be returned by Each()", matches)
+	}
+}
@@ -2,8 +2,8 @@ package structs
 
 import (
 	"encoding/json"
-	"io/ioutil"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 )
@@ -37,16 +37,24 @@ func NewChampPack(count int, maxID RiotID) *ChampPack {
 	}
 }
 
-// NewRiotChampPack : Create a new champpack populated with summoner stats. This function makes a
-// request to Riot's API for the latest champion list.
-func NewRiotChampPack() *ChampPack {
-	resp, err := http.Get("http://ddragon.leagueoflegends.com/cdn/7.15.1/data/en_US/champion.json")
-	if err != nil {
-		log.Fatal(err)
-	}
+// ddragonURL : Riot's static data, including the champion list for each patch.
+const ddragonURL = "http://ddragon.leagueoflegends.com"
+
+// NewRiotChampPack : Create a new champpack populated with every champion in Riot's champion list
+// as of patch `version` (i.e. "7.15.1"), or the latest patch if `version` is empty.
+func NewRiotChampPack(version string) (*ChampPack, error) {
+	if version == "" {
+		// Newest first.
+		var versions []string
+		if err := getDDragon("/api/versions.json", &versions); err != nil {
+			return nil, err
+		}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+		if len(versions) == 0 {
+			return nil, errors.New("ddragon didn't list any versions")
+		}
+		version = versions[0]
+	}
 
 	var rc struct {
 		Data map[string]struct {
@@ -55,7 +63,9 @@ func NewRiotChampPack() *ChampPack {
 		}
 	}
 
-	json.Unmarshal(body, &rc)
+	if err := getDDragon("/cdn/"+version+"/data/en_US/champion.json", &rc); err != nil {
+		return nil, err
+	}
 
 	count := len(rc.Data)
 	max := 0
@@ -77,7 +87,22 @@ func NewRiotChampPack() *ChampPack {
 		cp.AddRiotID(RiotID(id))
 	}
 
-	return cp
+	return cp, nil
+}
+
+// getDDragon : Request a file from ddragon and decode it into `v`.
+func getDDragon(path string, v interface{}) error {
+	resp, err := http.Get(ddragonURL + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ddragon returned %s for %s", resp.Status, path)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
 }
 
 // AddRiotID : Add a new Riot ID to the mapping. Returns the corresponding packedChampID.
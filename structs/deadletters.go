@@ -0,0 +1,66 @@
+package structs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Kinds of requests that can end up in the dead-letter list.
+const (
+	DeadMatch    = "match"
+	DeadSummoner = "summoner"
+	DeadTimeline = "timeline"
+)
+
+const deadLetterPrefix = "dead:"
+
+// DeadLetter : An ID that failed too many times to keep retrying. Dead letters are kept in the
+// match store so that they can be inspected and replayed later.
+type DeadLetter struct {
+	Kind     string    `json:"kind"`
+	Platform string    `json:"platform"`
+	ID       RiotID    `json:"id"`
+	Attempts int       `json:"attempts"`
+	Reason   string    `json:"reason"`
+	When     time.Time `json:"when"`
+}
+
+func (dl DeadLetter) key() []byte {
+	return append([]byte(deadLetterPrefix+dl.Kind+":"+dl.Platform+":"), dl.ID.Bytes()...)
+}
+
+// AddDeadLetter : Record an ID that permanently failed. Recording the same ID again replaces
+// the previous record.
+func (ms *MatchStore) AddDeadLetter(dl DeadLetter) error {
+	raw, err := json.Marshal(dl)
+	if err != nil {
+		return err
+	}
+
+	return ms.db.Put(dl.key(), raw, nil)
+}
+
+// RemoveDeadLetter : Remove a dead letter, usually after it's been replayed.
+func (ms *MatchStore) RemoveDeadLetter(dl DeadLetter) error {
+	return ms.db.Delete(dl.key(), nil)
+}
+
+// DeadLetters : Iterate over all recorded dead letters.
+func (ms *MatchStore) DeadLetters(fn func(DeadLetter)) error {
+	iter := ms.db.NewIterator(util.BytesPrefix([]byte(deadLetterPrefix)), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		var dl DeadLetter
+
+		if err := json.Unmarshal(iter.Value(), &dl); err != nil {
+			return err
+		}
+
+		fn(dl)
+	}
+
+	return iter.Error()
+}
@@ -0,0 +1,52 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// Make sure dead letters can be recorded, listed, and removed without showing up as matches.
+func TestDeadLetters(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(dir + SnapshotSuffix)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	dl := DeadLetter{Kind: DeadMatch, Platform: "NA1", ID: RiotID(42), Attempts: 5, Reason: "oops"}
+	store.AddDeadLetter(dl)
+	store.AddDeadLetter(DeadLetter{Kind: DeadSummoner, Platform: "EUW1", ID: RiotID(7)})
+
+	found := make([]DeadLetter, 0)
+	store.DeadLetters(func(d DeadLetter) {
+		found = append(found, d)
+	})
+
+	if len(found) != 2 {
+		t.FailNow()
+	}
+
+	store.Each(func(m *Match) {
+		t.Error("dead letter returned as a match")
+	})
+
+	store.RemoveDeadLetter(dl)
+	time.Sleep(100 * time.Millisecond)
+
+	count := 0
+	store.DeadLetters(func(d DeadLetter) {
+		if d.ID == dl.ID {
+			t.Error("dead letter not removed")
+		}
+
+		count++
+	})
+
+	if count != 1 {
+		t.Fail()
+	}
+}
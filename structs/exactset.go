@@ -0,0 +1,77 @@
+package structs
+
+import (
+	"encoding/binary"
+	"log"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// exactSet : Records every ID an IDList has seen in its own LevelDB database. Unlike the bloom
+// filter it never reports an ID it hasn't seen. Each table has a small bloom filter of its own
+// so lookups for new ID's usually don't touch the disk. Not safe for concurrent use; IDList
+// serializes access.
+type exactSet struct {
+	db    *leveldb.DB
+	count uint64
+}
+
+// ID's are stored as 8-byte keys, so the count can't collide with them.
+var exactCountKey = []byte("count")
+
+// openExactSet : Open (or create) a set. ID's recorded by a previous run are kept.
+func openExactSet(path string) (*exactSet, error) {
+	db, err := leveldb.OpenFile(path, &opt.Options{
+		Filter: filter.NewBloomFilter(10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	es := &exactSet{db: db}
+
+	raw, err := db.Get(exactCountKey, nil)
+	if err == nil {
+		es.count = binary.BigEndian.Uint64(raw)
+	} else if err != leveldb.ErrNotFound {
+		db.Close()
+		return nil, err
+	}
+
+	return es, nil
+}
+
+// has : Returns true if the ID has been added. Read errors are treated as unseen so that ID's
+// are requested twice rather than skipped.
+func (es *exactSet) has(id RiotID) bool {
+	found, err := es.db.Has(id.Bytes(), nil)
+	if err != nil {
+		log.Println("Couldn't check ID: " + err.Error())
+		return false
+	}
+
+	return found
+}
+
+// add : Record an ID. The caller must check has() first so the count stays accurate.
+func (es *exactSet) add(id RiotID) error {
+	count := make([]byte, 8)
+	binary.BigEndian.PutUint64(count, es.count+1)
+
+	batch := new(leveldb.Batch)
+	batch.Put(id.Bytes(), nil)
+	batch.Put(exactCountKey, count)
+
+	if err := es.db.Write(batch, nil); err != nil {
+		return err
+	}
+
+	es.count++
+	return nil
+}
+
+func (es *exactSet) close() error {
+	return es.db.Close()
+}
@@ -0,0 +1,62 @@
+package structs
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Names of the frontier lists that crawlers persist.
+const (
+	FrontierMatches   = "matches"
+	FrontierSummoners = "summoners"
+)
+
+const frontierPrefix = "frontier:"
+
+func frontierKey(platform string, name string) []byte {
+	return []byte(frontierPrefix + platform + ":" + name)
+}
+
+// SaveFrontier : Persist an IDList so that a crawl can pick up where it left off. Saving the
+// same platform and name again replaces the previous copy.
+func (ms *MatchStore) SaveFrontier(platform string, name string, list *IDList) error {
+	return ms.db.Put(frontierKey(platform, name), list.Bytes(), nil)
+}
+
+// LoadFrontier : Restore an IDList saved with SaveFrontier(). Returns nil without an error if
+// nothing has been saved yet.
+func (ms *MatchStore) LoadFrontier(platform string, name string) (*IDList, error) {
+	raw, err := ms.db.Get(frontierKey(platform, name), nil)
+
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return MakeIDList(raw)
+}
+
+func retriesKey(platform string, kind string) []byte {
+	return []byte(frontierPrefix + platform + ":retries:" + kind)
+}
+
+// SaveRetries : Persist a RetryQueue alongside the platform's frontier. `kind` is one of the
+// dead letter kinds (DeadMatch, etc). Saving the same platform and kind again replaces the
+// previous copy.
+func (ms *MatchStore) SaveRetries(platform string, kind string, rq *RetryQueue) error {
+	return ms.db.Put(retriesKey(platform, kind), rq.Bytes(), nil)
+}
+
+// LoadRetries : Add the ID's saved with SaveRetries() to a RetryQueue. Does nothing if nothing
+// has been saved yet.
+func (ms *MatchStore) LoadRetries(platform string, kind string, rq *RetryQueue) error {
+	raw, err := ms.db.Get(retriesKey(platform, kind), nil)
+
+	if err == leveldb.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return rq.Load(raw)
+}
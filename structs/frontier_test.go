@@ -0,0 +1,44 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// Make sure saved frontiers are restored per platform and don't show up as matches.
+func TestFrontier(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(dir + SnapshotSuffix)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	missing, err := store.LoadFrontier("NA1", FrontierMatches)
+	if missing != nil || err != nil {
+		t.Fatal("loaded a frontier that was never saved")
+	}
+
+	na := NewIDList()
+	na.Add(RiotID(1))
+	euw := NewIDList()
+	euw.Add(RiotID(2))
+
+	store.SaveFrontier("NA1", FrontierMatches, na)
+	store.SaveFrontier("EUW1", FrontierMatches, euw)
+
+	restored, err := store.LoadFrontier("EUW1", FrontierMatches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id, _ := restored.Next(); id != 2 {
+		t.Errorf("restored the wrong frontier: %d", id)
+	}
+
+	store.Each(func(m *Match) {
+		t.Error("frontier returned as a match")
+	})
+}
@@ -0,0 +1,240 @@
+package structs
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Problems that Fsck() can find with a match record.
+const (
+	ProblemCorrupt        = "corrupt"         // can't be decoded
+	ProblemSchema         = "unknown schema"  // written by a newer version of matchgrab
+	ProblemKey            = "wrong key"       // stored under a key that doesn't match its platform and GameID
+	ProblemNoParticipants = "no participants" // i.e. an empty response stored as a match
+	ProblemParticipants   = "participants"    // unusual number of participants for the map
+	ProblemTimestamp      = "timestamp"       // creation time or duration out of range
+	ProblemUnknownChamps  = "unknown champion"
+)
+
+// reportOnly : Problems that valid matches can have too (i.e. Hexakill has 12 participants, and
+// new champions aren't in older champion lists). Records that only have these are reported but
+// never removed.
+var reportOnly = map[string]bool{
+	ProblemParticipants:  true,
+	ProblemUnknownChamps: true,
+}
+
+// quarantinePrefix : Bad records moved aside by Fsck() are kept under this prefix followed by
+// their original key.
+const quarantinePrefix = "quarantine:"
+
+const (
+	// No match can have been created before League launched, or (allowing for clock skew) in
+	// the future.
+	earliestMatch = 1256601600000 // 2009-10-27, in epoch milliseconds
+	clockSkew     = 24 * time.Hour
+
+	// Longest game duration (in seconds) considered plausible.
+	longestMatch = 4 * 60 * 60
+
+	twistedTreeline = 10 // map ID; 3v3
+
+	// Removals are written in batches of this size.
+	fsckBatchSize = 1000
+)
+
+// FsckOptions : What Fsck() checks and how it repairs bad records. Records are only reported
+// unless Quarantine or Delete is set, and records that only have report-only problems are never
+// removed.
+type FsckOptions struct {
+	Champions *ChampPack // known champions; nil skips the champion check
+
+	Quarantine bool // move removable records under the quarantine: prefix
+	Delete     bool // remove removable records entirely
+
+	// Rebuild the store's counts and indexes even if no records were removed. They're always
+	// rebuilt when records are.
+	Rebuild bool
+}
+
+// FsckProblem : A match record that failed one or more checks. Platform and GameID are only set
+// if the record could be decoded.
+type FsckProblem struct {
+	Key      []byte
+	Platform string
+	GameID   RiotID
+	Problems []string
+	Detail   string // error or description of the first problem
+}
+
+// Removable : Returns true if the record has a problem that Fsck() can repair by removing it.
+func (p FsckProblem) Removable() bool {
+	for _, problem := range p.Problems {
+		if !reportOnly[problem] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FsckReport : Summary of everything Fsck() found and did.
+type FsckReport struct {
+	Scanned    int            // match records checked
+	Bad        int            // records with at least one problem
+	ReportOnly int            // bad records that aren't removable; included in Bad
+	ByProblem  map[string]int // records with each problem
+	Removed    int            // bad records quarantined or deleted
+	Rebuilt    bool           // counts and indexes were rebuilt
+}
+
+// Fsck : Check every match record in the store: that it decodes, is stored under its own key,
+// has a usual number of participants, has a plausible creation time and duration, and (if
+// opts.Champions is set) only has known champions. `fn` is called for every bad record.
+// Removable records (see FsckProblem.Removable()) are quarantined or deleted if requested, after
+// which the store's counts and indexes are rebuilt.
+func (ms *MatchStore) Fsck(opts FsckOptions, fn func(FsckProblem)) (FsckReport, error) {
+	report := FsckReport{ByProblem: make(map[string]int)}
+
+	iter := ms.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	// Iterators read from an implicit snapshot, so removing records doesn't affect this one.
+	batch := new(leveldb.Batch)
+	latest := time.Now().Add(clockSkew)
+
+	for iter.Next() {
+		if !isMatchKey(iter.Key()) {
+			continue
+		}
+
+		report.Scanned++
+
+		problem := checkRecord(iter.Key(), iter.Value(), opts.Champions, latest)
+		if len(problem.Problems) == 0 {
+			continue
+		}
+
+		report.Bad++
+		for _, p := range problem.Problems {
+			report.ByProblem[p]++
+		}
+
+		if fn != nil {
+			fn(problem)
+		}
+
+		if !problem.Removable() {
+			report.ReportOnly++
+			continue
+		}
+
+		if !opts.Quarantine && !opts.Delete {
+			continue
+		}
+
+		if opts.Quarantine {
+			batch.Put(append([]byte(quarantinePrefix), problem.Key...), iter.Value())
+		}
+		batch.Delete(problem.Key)
+		report.Removed++
+
+		if batch.Len() >= fsckBatchSize {
+			if err := ms.db.Write(batch, nil); err != nil {
+				return report, err
+			}
+			batch.Reset()
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return report, err
+	}
+
+	if err := ms.db.Write(batch, nil); err != nil {
+		return report, err
+	}
+
+	if report.Removed == 0 && !opts.Rebuild {
+		return report, nil
+	}
+
+	if err := ms.RebuildStats(); err != nil {
+		return report, err
+	}
+	if _, err := ms.Reindex(); err != nil {
+		return report, err
+	}
+
+	report.Rebuilt = true
+	return report, nil
+}
+
+// checkRecord : Run every check on a single record.
+func checkRecord(key []byte, value []byte, champions *ChampPack, latest time.Time) FsckProblem {
+	problem := FsckProblem{Key: append([]byte{}, key...)}
+
+	add := func(p string, detail string) {
+		problem.Problems = append(problem.Problems, p)
+		if problem.Detail == "" {
+			problem.Detail = detail
+		}
+	}
+
+	m, err := MakeMatch(value)
+	if err != nil {
+		if _, ok := err.(UnknownSchemaError); ok {
+			add(ProblemSchema, err.Error())
+		} else {
+			add(ProblemCorrupt, err.Error())
+		}
+
+		return problem
+	}
+
+	problem.Platform = m.PlatformID
+	problem.GameID = m.GameID
+
+	// Records that haven't been migrated are still keyed by GameID alone.
+	legacy := m.PlatformID == LegacyPlatform && bytes.Equal(key, m.GameID.Bytes())
+	if m.GameID == 0 {
+		add(ProblemKey, "game ID is 0")
+	} else if !bytes.Equal(key, m.Key()) && !legacy {
+		add(ProblemKey, fmt.Sprintf("stored under %x, expected %x", key, m.Key()))
+	}
+
+	expected := 10
+	if m.MapID == twistedTreeline {
+		expected = 6
+	}
+	if len(m.Participants) == 0 {
+		add(ProblemNoParticipants, "no participants")
+	} else if len(m.Participants) != expected {
+		add(ProblemParticipants, fmt.Sprintf("%d participants, expected %d", len(m.Participants), expected))
+	}
+
+	if m.GameCreation < earliestMatch || m.When().After(latest) {
+		add(ProblemTimestamp, fmt.Sprintf("created %s", m.When().UTC().Format(time.RFC3339)))
+	} else if m.GameDuration <= 0 || m.GameDuration > longestMatch {
+		add(ProblemTimestamp, fmt.Sprintf("lasted %d seconds", m.GameDuration))
+	}
+
+	if champions != nil {
+		for _, p := range m.Participants {
+			known := false
+			if p.ChampionID > 0 {
+				_, known = champions.GetPacked(p.ChampionID)
+			}
+
+			if !known {
+				add(ProblemUnknownChamps, fmt.Sprintf("unknown champion %d", p.ChampionID))
+				break
+			}
+		}
+	}
+
+	return problem
+}
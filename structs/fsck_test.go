@@ -0,0 +1,102 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// checkedMatch : A match that passes every check.
+func checkedMatch(id RiotID) Match {
+	m := Match{
+		GameID:       id,
+		PlatformID:   "NA1",
+		SeasonID:     9,
+		GameCreation: toMillis(time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)),
+		GameDuration: 1800,
+	}
+
+	for i := 0; i < 10; i++ {
+		m.Participants = append(m.Participants, Participant{AccountID: RiotID(100 + i), ChampionID: RiotID(i + 1)})
+	}
+
+	return m
+}
+
+// Make sure Fsck() finds each kind of bad record and can quarantine them.
+func TestFsck(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	champions := NewChampPack(10, 10)
+	for i := 1; i <= 10; i++ {
+		champions.AddRiotID(RiotID(i))
+	}
+
+	store.Add(checkedMatch(1))
+
+	few := checkedMatch(2)
+	few.Participants = few.Participants[:2]
+	store.Add(few)
+
+	future := checkedMatch(3)
+	future.GameCreation = toMillis(time.Now().Add(30 * 24 * time.Hour))
+	store.Add(future)
+
+	unknown := checkedMatch(4)
+	unknown.Participants[3].ChampionID = 999
+	store.Add(unknown)
+
+	store.Flush()
+
+	// An error body stored as a match, and an empty match (with no creation time either) stored
+	// under someone else's key.
+	store.db.Put(MatchKey("NA1", 5), []byte("Not found"), nil)
+	store.db.Put(MatchKey("NA1", 6), Match{PlatformID: "NA1"}.Bytes(), nil)
+
+	found := make(map[RiotID][]string)
+	report, err := store.Fsck(FsckOptions{Champions: champions}, func(p FsckProblem) {
+		found[p.GameID] = append(found[p.GameID], p.Problems...)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Scanned != 6 || report.Bad != 5 || report.ReportOnly != 2 || report.Removed != 0 || report.Rebuilt {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if report.ByProblem[ProblemCorrupt] != 1 || report.ByProblem[ProblemKey] != 1 || report.ByProblem[ProblemTimestamp] != 2 ||
+		report.ByProblem[ProblemUnknownChamps] != 1 || report.ByProblem[ProblemParticipants] != 1 || report.ByProblem[ProblemNoParticipants] != 1 {
+		t.Errorf("unexpected problems: %v", report.ByProblem)
+	}
+	if len(found[1]) != 0 || len(found[2]) != 1 || found[4][0] != ProblemUnknownChamps {
+		t.Errorf("unexpected problems: %v", found)
+	}
+
+	report, err = store.Fsck(FsckOptions{Champions: champions, Quarantine: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Removed != 3 || !report.Rebuilt {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	// Too few participants and unknown champions are only reported.
+	if store.Count() != 3 {
+		t.Errorf("%d matches left after quarantining", store.Count())
+	}
+	if games, _ := store.ByAccount("NA1", 100); len(games) != 3 || games[0] != 1 {
+		t.Errorf("index wasn't rebuilt: %v", games)
+	}
+	if raw, err := store.db.Get(append([]byte(quarantinePrefix), MatchKey("NA1", 5)...), nil); err != nil || string(raw) != "Not found" {
+		t.Errorf("bad record wasn't quarantined: %q %v", raw, err)
+	}
+
+	if report, _ := store.Fsck(FsckOptions{Champions: champions}, nil); report.Scanned != 3 || report.Bad != 2 || report.ReportOnly != 2 {
+		t.Errorf("unexpected report after quarantining: %+v", report)
+	}
+}
@@ -0,0 +1,206 @@
+package structs
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Index keyspaces. Each index entry is a key with an empty value: the index prefix and
+// platform, then the indexed value and the GameID, both big-endian. Entries for the same value
+// are therefore adjacent and sorted by GameID (or by creation time, for the time index).
+const (
+	indexPrefix         = "idx:"
+	accountIndexPrefix  = indexPrefix + "account:"
+	championIndexPrefix = indexPrefix + "champion:"
+	timeIndexPrefix     = indexPrefix + "time:"
+)
+
+// reindexBatchSize : Most stale index entries Reindex() deletes in a single batch.
+const reindexBatchSize = 1000
+
+func indexKey(prefix string, platform string, value int64, id RiotID) []byte {
+	key := make([]byte, 0, len(prefix)+len(platform)+17)
+	key = append(key, prefix+platform+":"...)
+	key = append(key, RiotID(value).Bytes()...)
+
+	return append(key, id.Bytes()...)
+}
+
+// indexPlatform : Legacy records don't have a platform, so they're indexed as LegacyPlatform
+// (which is also where Get() looks for them).
+func indexPlatform(m *Match) string {
+	if m.PlatformID == "" {
+		return LegacyPlatform
+	}
+
+	return m.PlatformID
+}
+
+// indexKeys : Returns the key of every index entry for a match.
+func indexKeys(m *Match) [][]byte {
+	platform := indexPlatform(m)
+	keys := make([][]byte, 0, 2*len(m.Participants)+1)
+
+	for _, p := range m.Participants {
+		keys = append(keys, indexKey(accountIndexPrefix, platform, int64(p.AccountID), m.GameID))
+		keys = append(keys, indexKey(championIndexPrefix, platform, int64(p.ChampionID), m.GameID))
+	}
+
+	return append(keys, indexKey(timeIndexPrefix, platform, m.GameCreation, m.GameID))
+}
+
+// addIndexes : Add index entries for a match to a batch.
+func addIndexes(batch *leveldb.Batch, m *Match) {
+	for _, key := range indexKeys(m) {
+		batch.Put(key, nil)
+	}
+}
+
+// removeIndexes : Remove the index entries for a match that's being replaced or removed. Call
+// before addIndexes() for the replacement, since batches are applied in order.
+func removeIndexes(batch *leveldb.Batch, m *Match) {
+	for _, key := range indexKeys(m) {
+		batch.Delete(key)
+	}
+}
+
+// addMatch : Add a match to a batch along with all of its index entries, so the indexes never
+// disagree with the stored matches.
+func addMatch(batch *leveldb.Batch, m *Match) {
+	batch.Put(m.Key(), m.Bytes())
+	addIndexes(batch, m)
+}
+
+// scanIndex : Returns the GameID's of all index entries in the specified range.
+func (ms *MatchStore) scanIndex(r *util.Range) ([]RiotID, error) {
+	iter := ms.db.NewIterator(r, nil)
+	defer iter.Release()
+
+	ids := make([]RiotID, 0)
+	for iter.Next() {
+		key := iter.Key()
+		ids = append(ids, RiotID(binary.BigEndian.Uint64(key[len(key)-8:])))
+	}
+
+	return ids, iter.Error()
+}
+
+// ByAccount : Returns the GameID's of every stored match on the platform that the account
+// played in, in GameID order.
+func (ms *MatchStore) ByAccount(platform string, account RiotID) ([]RiotID, error) {
+	prefix := append([]byte(accountIndexPrefix+platform+":"), account.Bytes()...)
+
+	return ms.scanIndex(util.BytesPrefix(prefix))
+}
+
+// ByChampion : Returns the GameID's of every stored match on the platform that the champion
+// was picked in, in GameID order.
+func (ms *MatchStore) ByChampion(platform string, champion RiotID) ([]RiotID, error) {
+	prefix := append([]byte(championIndexPrefix+platform+":"), champion.Bytes()...)
+
+	return ms.scanIndex(util.BytesPrefix(prefix))
+}
+
+// Accounts : Iterate over every account that has a stored match on the platform, in account ID
+// order, along with the GameID's of its matches.
+func (ms *MatchStore) Accounts(platform string, fn func(account RiotID, games []RiotID)) error {
+	prefix := accountIndexPrefix + platform + ":"
+
+	iter := ms.db.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+	defer iter.Release()
+
+	var account RiotID
+	var games []RiotID
+
+	for iter.Next() {
+		key := iter.Key()[len(prefix):]
+		if len(key) != 16 {
+			continue
+		}
+
+		next := RiotID(binary.BigEndian.Uint64(key[:8]))
+		if next != account && len(games) > 0 {
+			fn(account, games)
+			games = nil
+		}
+
+		account = next
+		games = append(games, RiotID(binary.BigEndian.Uint64(key[8:])))
+	}
+
+	if len(games) > 0 {
+		fn(account, games)
+	}
+
+	return iter.Error()
+}
+
+// Between : Returns the GameID's of every stored match on the platform created at or after
+// `start` and before `end`, oldest first.
+func (ms *MatchStore) Between(platform string, start time.Time, end time.Time) ([]RiotID, error) {
+	prefix := timeIndexPrefix + platform + ":"
+
+	return ms.scanIndex(&util.Range{
+		Start: append([]byte(prefix), RiotID(toMillis(start)).Bytes()...),
+		Limit: append([]byte(prefix), RiotID(toMillis(end)).Bytes()...),
+	})
+}
+
+// Reindex : Rebuild all indexes from the stored matches, i.e. for stores created before indexes
+// were kept. Returns the number of matches indexed.
+func (ms *MatchStore) Reindex() (int, error) {
+	iter := ms.db.NewIterator(util.BytesPrefix([]byte(indexPrefix)), nil)
+
+	// Stores can have millions of index entries, so they're deleted a chunk at a time.
+	stale := new(leveldb.Batch)
+	for iter.Next() {
+		stale.Delete(append([]byte{}, iter.Key()...))
+
+		if stale.Len() >= reindexBatchSize {
+			if err := ms.db.Write(stale, nil); err != nil {
+				iter.Release()
+				return 0, err
+			}
+			stale.Reset()
+		}
+	}
+	iter.Release()
+
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	if err := ms.db.Write(stale, nil); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var writeErr error
+
+	ms.Each(func(m *Match) {
+		if writeErr != nil {
+			return
+		}
+
+		batch := new(leveldb.Batch)
+		addIndexes(batch, m)
+
+		if writeErr = ms.db.Write(batch, nil); writeErr == nil {
+			count++
+		}
+	})
+
+	return count, writeErr
+}
+
+// toMillis : Convert to the millisecond timestamps Riot uses for GameCreation. Times before 1970
+// (including the zero time) are clamped to zero, since index keys compare as unsigned.
+func toMillis(t time.Time) int64 {
+	if t.Before(time.Unix(0, 0)) {
+		return 0
+	}
+
+	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+}
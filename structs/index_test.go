@@ -0,0 +1,123 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func indexedMatch(platform string, id RiotID, created time.Time, accounts ...RiotID) Match {
+	m := Match{GameID: id, PlatformID: platform, GameCreation: toMillis(created)}
+
+	for i, account := range accounts {
+		m.Participants = append(m.Participants, Participant{AccountID: account, ChampionID: RiotID(i + 1)})
+	}
+
+	return m
+}
+
+// Make sure matches can be found by account, champion and creation time, and that the indexes
+// can be rebuilt.
+func TestIndexes(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(dir + SnapshotSuffix)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	day := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	store.Add(indexedMatch("NA1", RiotID(30), day, 100, 200))
+	store.Add(indexedMatch("NA1", RiotID(10), day.Add(2*time.Hour), 100, 300))
+	store.Add(indexedMatch("NA1", RiotID(20), day.Add(time.Hour), 200, 100))
+	store.Add(indexedMatch("EUW1", RiotID(10), day, 100))
+	store.Add(indexedMatch("", RiotID(40), day.Add(3*time.Hour), 100))
+
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func() {
+		ids, err := store.ByAccount("NA1", RiotID(100))
+		if err != nil || !reflect.DeepEqual(ids, []RiotID{10, 20, 30, 40}) {
+			t.Errorf("ByAccount returned %v (%v)", ids, err)
+		}
+
+		ids, _ = store.ByAccount("EUW1", RiotID(100))
+		if !reflect.DeepEqual(ids, []RiotID{10}) {
+			t.Errorf("ByAccount returned %v for EUW1", ids)
+		}
+
+		// Champion 2 is picked by the second participant in each match.
+		ids, _ = store.ByChampion("NA1", RiotID(2))
+		if !reflect.DeepEqual(ids, []RiotID{10, 20, 30}) {
+			t.Errorf("ByChampion returned %v", ids)
+		}
+
+		ids, _ = store.Between("NA1", day, day.Add(3*time.Hour))
+		if !reflect.DeepEqual(ids, []RiotID{30, 20, 10}) {
+			t.Errorf("Between returned %v", ids)
+		}
+
+		ids, _ = store.Between("NA1", time.Time{}, day.Add(time.Hour))
+		if !reflect.DeepEqual(ids, []RiotID{30}) {
+			t.Errorf("Between returned %v for an open start", ids)
+		}
+
+		accounts := make(map[RiotID]int)
+		store.Accounts("NA1", func(account RiotID, games []RiotID) {
+			accounts[account] = len(games)
+		})
+
+		if !reflect.DeepEqual(accounts, map[RiotID]int{100: 4, 200: 2, 300: 1}) {
+			t.Errorf("Accounts returned %v", accounts)
+		}
+	}
+
+	check()
+
+	if count, err := store.Reindex(); err != nil || count != 5 {
+		t.Fatalf("indexed %d matches: %v", count, err)
+	}
+
+	check()
+
+	matches := 0
+	store.Each(func(m *Match) {
+		matches++
+	})
+
+	if matches != 5 {
+		t.Errorf("found %d matches; index entries shouldn't be returned by Each()", matches)
+	}
+}
+
+// Make sure replacing a match removes the index entries that no longer apply.
+func TestIndexesReplaced(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	day := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	store.Add(indexedMatch("NA1", RiotID(10), day, 100, 200))
+	store.Flush()
+	store.Add(indexedMatch("NA1", RiotID(10), day.Add(time.Hour), 100, 300))
+	store.Flush()
+
+	if ids, _ := store.ByAccount("NA1", RiotID(200)); len(ids) != 0 {
+		t.Errorf("stale account entry left behind: %v", ids)
+	}
+	if ids, _ := store.ByAccount("NA1", RiotID(300)); !reflect.DeepEqual(ids, []RiotID{10}) {
+		t.Errorf("ByAccount returned %v", ids)
+	}
+	if ids, _ := store.Between("NA1", day, day.Add(time.Minute)); len(ids) != 0 {
+		t.Errorf("stale time entry left behind: %v", ids)
+	}
+}
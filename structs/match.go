@@ -3,8 +3,10 @@ package structs
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"log"
@@ -44,6 +46,15 @@ type APIMatch struct {
 		Masteries  []rawMastery `json:"masteries"`
 		Runes      []rawRune    `json:"runes"`
 
+		Spell1ID                  int    `json:"spell1Id"`
+		Spell2ID                  int    `json:"spell2Id"`
+		HighestAchievedSeasonTier string `json:"highestAchievedSeasonTier"`
+
+		Timeline struct {
+			Lane string `json:"lane"`
+			Role string `json:"role"`
+		} `json:"timeline"`
+
 		Stats struct {
 			Win   bool `json:"win"`
 			Item0 int32
@@ -110,6 +121,34 @@ type APIMatch struct {
 			ObjectivePlayerScore            int32
 			TotalPlayerScore                int32
 			TotalScoreRank                  int32
+
+			// Runes Reforged; see Perk.
+			PerkPrimaryStyle int32
+			PerkSubStyle     int32
+			Perk0            int32
+			Perk0Var1        int32
+			Perk0Var2        int32
+			Perk0Var3        int32
+			Perk1            int32
+			Perk1Var1        int32
+			Perk1Var2        int32
+			Perk1Var3        int32
+			Perk2            int32
+			Perk2Var1        int32
+			Perk2Var2        int32
+			Perk2Var3        int32
+			Perk3            int32
+			Perk3Var1        int32
+			Perk3Var2        int32
+			Perk3Var3        int32
+			Perk4            int32
+			Perk4Var1        int32
+			Perk4Var2        int32
+			Perk4Var3        int32
+			Perk5            int32
+			Perk5Var1        int32
+			Perk5Var2        int32
+			Perk5Var3        int32
 		} `json:"stats"`
 	}
 
@@ -123,14 +162,35 @@ type APIMatch struct {
 	}
 
 	Teams []struct {
+		TeamID int    `json:"teamId"`
+		Win    string `json:"win"` // "Win" or "Fail"
+
 		Bans []struct {
 			ChampionID RiotID `json:"championId"`
+			PickTurn   int    `json:"pickTurn"`
 		} `json:"bans"`
-	}
+
+		FirstBlood      bool `json:"firstBlood"`
+		FirstTower      bool `json:"firstTower"`
+		FirstInhibitor  bool `json:"firstInhibitor"`
+		FirstBaron      bool `json:"firstBaron"`
+		FirstDragon     bool `json:"firstDragon"`
+		FirstRiftHerald bool `json:"firstRiftHerald"`
+
+		TowerKills      int `json:"towerKills"`
+		InhibitorKills  int `json:"inhibitorKills"`
+		BaronKills      int `json:"baronKills"`
+		DragonKills     int `json:"dragonKills"`
+		RiftHeraldKills int `json:"riftHeraldKills"`
+	} `json:"teams"`
 
 	GameMode string `json:"gameMode"`
 	MapID    int    `json:"mapId"`
 	GameType string `json:"gameType"`
+	QueueID  int    `json:"queueId"`
+
+	GameVersion string `json:"gameVersion"`
+	PlatformID  string `json:"platformId"`
 }
 
 // RiotID : Canonical identifier for everything that comes from Riot, including summoner ID's,
@@ -161,12 +221,23 @@ type Match struct {
 	GameDuration int    `json:"gameDuration"`
 
 	Participants []Participant
-	Bans         []RiotID
+	Bans         []RiotID // every team's bans; see Teams for which team made each one
+	Teams        []Team
 
 	GameMode string `json:"gameMode"`
 	MapID    int    `json:"mapId"`
 	GameType string `json:"gameType"`
 
+	// Queue the match was played in, i.e. 420 for ranked solo.
+	QueueID int `json:"queueId"`
+
+	// Full version of the game client, i.e. 7.10.187.9675. See Patch().
+	GameVersion string `json:"gameVersion"`
+
+	// Platform the match was retrieved from (NA1, EUW1, etc). Game ID's are only unique
+	// within a platform.
+	PlatformID string `json:"platformId"`
+
 	packed             bool
 	packedBans         *PackedChampBooleanArray
 	packedPicked       *PackedChampBooleanArray
@@ -222,10 +293,26 @@ func (m *Match) Pack(packer *ChampPack) {
 	}
 }
 
+// Key : Returns the key used to store this match. See MatchKey().
+func (m Match) Key() []byte {
+	return MatchKey(m.PlatformID, m.GameID)
+}
+
 func (m *Match) When() time.Time {
 	return time.Unix(m.GameCreation/1000, 0)
 }
 
+// Patch : Returns the major.minor patch the match was played on (i.e. "7.10" for game version
+// 7.10.187.9675), or an empty string if the game version wasn't recorded.
+func (m *Match) Patch() string {
+	parts := strings.SplitN(m.GameVersion, ".", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
 func (m *Match) Banned(id RiotID) bool {
 	// Constant time if packed, linear if not packed
 	if m.packed {
@@ -302,6 +389,11 @@ func (m Match) Bytes() []byte {
 		bans = append(bans, int64(b))
 	}
 
+	teams := make([]*protostruct.Team, 0, len(m.Teams))
+	for _, t := range m.Teams {
+		teams = append(teams, t.toProto())
+	}
+
 	participants := make([]*protostruct.Participant, 0, len(m.Participants))
 
 	for _, p := range m.Participants {
@@ -368,6 +460,10 @@ func (m Match) Bytes() []byte {
 				ObjectivePlayerScore:            p.Stats.ObjectivePlayerScore,
 				TotalPlayerScore:                p.Stats.TotalPlayerScore,
 				TotalScoreRank:                  p.Stats.TotalScoreRank,
+
+				PerkPrimaryStyle: p.Stats.PerkPrimaryStyle,
+				PerkSubStyle:     p.Stats.PerkSubStyle,
+				Perks:            perksToProto(p.Stats.Perks),
 			}
 		}
 
@@ -380,6 +476,13 @@ func (m Match) Bytes() []byte {
 			TeamID:       int32(p.TeamID),
 			Winner:       p.Winner,
 
+			Lane:   p.Lane,
+			Role:   p.Role,
+			Spell1: int32(p.Spell1),
+			Spell2: int32(p.Spell2),
+
+			HighestAchievedSeasonTier: p.HighestAchievedSeasonTier,
+
 			Stats: stats,
 		})
 	}
@@ -391,10 +494,16 @@ func (m Match) Bytes() []byte {
 		GameDuration: int32(m.GameDuration),
 		Participants: participants,
 		Bans:         bans,
+		Teams:        teams,
+
+		GameMode:    m.GameMode,
+		MapID:       int32(m.MapID),
+		GameType:    m.GameType,
+		PlatformID:  m.PlatformID,
+		QueueID:     int32(m.QueueID),
+		GameVersion: m.GameVersion,
 
-		GameMode: m.GameMode,
-		MapID:    int32(m.MapID),
-		GameType: m.GameType,
+		SchemaVersion: SchemaVersion,
 	}
 
 	buf, _ := proto.Marshal(p)
@@ -403,11 +512,34 @@ func (m Match) Bytes() []byte {
 }
 
 // MakeMatch : Convert an encoded byte array back into a match. This is the inverse
-// of Match.Bytes().
-func MakeMatch(buf []byte) *Match {
+// of Match.Bytes(). Records written with an older schema are upgraded to the current one.
+// Returns a CorruptRecordError if the record can't be decoded, or an UnknownSchemaError if it
+// was written by a newer version of matchgrab.
+func MakeMatch(buf []byte) (*Match, error) {
+	m, _, err := decodeMatch(buf)
+
+	return m, err
+}
+
+// decodeMatch : Decode and upgrade a record, returning the schema version it was written with.
+func decodeMatch(buf []byte) (*Match, int, error) {
+	if len(buf) == 0 {
+		return nil, 0, CorruptRecordError{Err: errors.New("empty record")}
+	}
+
 	pm := protostruct.Match{}
 
-	proto.Unmarshal(buf, &pm)
+	if err := proto.Unmarshal(buf, &pm); err != nil {
+		return nil, 0, CorruptRecordError{Err: err}
+	}
+
+	version := int(pm.GetSchemaVersion())
+	if version == 0 {
+		version = 1
+	}
+	if version > SchemaVersion {
+		return nil, version, UnknownSchemaError{Version: version}
+	}
 
 	// Convert ban list
 	bans := make([]RiotID, 0, len(pm.Bans))
@@ -415,6 +547,12 @@ func MakeMatch(buf []byte) *Match {
 		bans = append(bans, RiotID(b))
 	}
 
+	// Convert team list
+	teams := make([]Team, 0, len(pm.Teams))
+	for _, t := range pm.Teams {
+		teams = append(teams, makeTeam(t))
+	}
+
 	// Convert participant list
 	participants := make([]Participant, 0, len(pm.Participants))
 	for _, p := range pm.Participants {
@@ -478,6 +616,10 @@ func MakeMatch(buf []byte) *Match {
 				ObjectivePlayerScore:            p.Stats.GetObjectivePlayerScore(),
 				TotalPlayerScore:                p.Stats.GetTotalPlayerScore(),
 				TotalScoreRank:                  p.Stats.GetTotalScoreRank(),
+
+				PerkPrimaryStyle: p.Stats.GetPerkPrimaryStyle(),
+				PerkSubStyle:     p.Stats.GetPerkSubStyle(),
+				Perks:            makePerks(p.Stats.GetPerks()),
 			}
 		}
 
@@ -490,6 +632,13 @@ func MakeMatch(buf []byte) *Match {
 			TeamID:       int(p.GetTeamID()),
 			Winner:       p.GetWinner(),
 
+			Lane:   p.GetLane(),
+			Role:   p.GetRole(),
+			Spell1: int(p.GetSpell1()),
+			Spell2: int(p.GetSpell2()),
+
+			HighestAchievedSeasonTier: p.GetHighestAchievedSeasonTier(),
+
 			Stats: stats,
 		})
 	}
@@ -501,12 +650,18 @@ func MakeMatch(buf []byte) *Match {
 		GameDuration: int(pm.GetGameDuration()),
 		Participants: participants,
 		Bans:         bans,
+		Teams:        teams,
 		GameMode:     pm.GetGameMode(),
 		MapID:        int(pm.GetMapID()),
 		GameType:     pm.GetGameType(),
+		PlatformID:   pm.GetPlatformID(),
+		QueueID:      int(pm.GetQueueID()),
+		GameVersion:  pm.GetGameVersion(),
 	}
 
-	return m
+	upgrade(m, version)
+
+	return m, version, nil
 }
 
 // Participant : Stores information about individual players, including stats if requested.
@@ -520,6 +675,14 @@ type Participant struct {
 
 	Winner bool `json:"winner"`
 
+	// Position and summoner spells are kept even when stats aren't.
+	Lane   string `json:"lane"` // i.e. TOP, JUNGLE, MIDDLE, BOTTOM
+	Role   string `json:"role"` // i.e. SOLO, DUO_CARRY, DUO_SUPPORT, NONE
+	Spell1 int    `json:"spell1Id"`
+	Spell2 int    `json:"spell2Id"`
+
+	HighestAchievedSeasonTier string `json:"highestAchievedSeasonTier"` // i.e. GOLD; UNRANKED if none
+
 	Masteries []int32
 	Runes     []int32
 	Items     []int32
@@ -584,6 +747,12 @@ type ParticipantStats struct {
 	ObjectivePlayerScore            int32
 	TotalPlayerScore                int32
 	TotalScoreRank                  int32
+
+	// Runes Reforged. Empty for matches played before patch 7.22 and for records stored before
+	// perks were kept.
+	PerkPrimaryStyle int32
+	PerkSubStyle     int32
+	Perks            []Perk
 }
 
 // ToMatch : Convert raw API data to a Match object
@@ -598,6 +767,9 @@ func ToMatch(raw APIMatch) Match {
 	match.GameMode = raw.GameMode
 	match.MapID = raw.MapID
 	match.GameType = raw.GameType
+	match.QueueID = raw.QueueID
+	match.GameVersion = raw.GameVersion
+	match.PlatformID = raw.PlatformID
 
 	match.Participants = make([]Participant, len(raw.Participants))
 
@@ -664,6 +836,17 @@ func ToMatch(raw APIMatch) Match {
 				CombatPlayerScore:               p.Stats.CombatPlayerScore,
 				TotalPlayerScore:                p.Stats.TotalPlayerScore,
 				TotalScoreRank:                  p.Stats.TotalScoreRank,
+
+				PerkPrimaryStyle: p.Stats.PerkPrimaryStyle,
+				PerkSubStyle:     p.Stats.PerkSubStyle,
+				Perks: selectedPerks([]Perk{
+					{ID: p.Stats.Perk0, Var1: p.Stats.Perk0Var1, Var2: p.Stats.Perk0Var2, Var3: p.Stats.Perk0Var3},
+					{ID: p.Stats.Perk1, Var1: p.Stats.Perk1Var1, Var2: p.Stats.Perk1Var2, Var3: p.Stats.Perk1Var3},
+					{ID: p.Stats.Perk2, Var1: p.Stats.Perk2Var1, Var2: p.Stats.Perk2Var2, Var3: p.Stats.Perk2Var3},
+					{ID: p.Stats.Perk3, Var1: p.Stats.Perk3Var1, Var2: p.Stats.Perk3Var2, Var3: p.Stats.Perk3Var3},
+					{ID: p.Stats.Perk4, Var1: p.Stats.Perk4Var1, Var2: p.Stats.Perk4Var2, Var3: p.Stats.Perk4Var3},
+					{ID: p.Stats.Perk5, Var1: p.Stats.Perk5Var1, Var2: p.Stats.Perk5Var2, Var3: p.Stats.Perk5Var3},
+				}),
 			}
 		}
 
@@ -677,6 +860,13 @@ func ToMatch(raw APIMatch) Match {
 			SummonerName: pi.Player.SummonerName,
 			Winner:       p.Stats.Win,
 
+			Lane:   p.Timeline.Lane,
+			Role:   p.Timeline.Role,
+			Spell1: p.Spell1ID,
+			Spell2: p.Spell2ID,
+
+			HighestAchievedSeasonTier: p.HighestAchievedSeasonTier,
+
 			Masteries: make([]int32, 0),
 			Runes:     make([]int32, 0),
 			Items: []int32{
@@ -702,10 +892,34 @@ func ToMatch(raw APIMatch) Match {
 	}
 
 	match.Bans = make([]RiotID, 0)
+	match.Teams = make([]Team, 0, len(raw.Teams))
+
 	for _, team := range raw.Teams {
+		t := Team{
+			TeamID: team.TeamID,
+			Winner: team.Win == "Win",
+			Bans:   make([]Ban, 0, len(team.Bans)),
+
+			FirstBlood:      team.FirstBlood,
+			FirstTower:      team.FirstTower,
+			FirstInhibitor:  team.FirstInhibitor,
+			FirstBaron:      team.FirstBaron,
+			FirstDragon:     team.FirstDragon,
+			FirstRiftHerald: team.FirstRiftHerald,
+
+			TowerKills:      team.TowerKills,
+			InhibitorKills:  team.InhibitorKills,
+			BaronKills:      team.BaronKills,
+			DragonKills:     team.DragonKills,
+			RiftHeraldKills: team.RiftHeraldKills,
+		}
+
 		for _, ban := range team.Bans {
 			match.Bans = append(match.Bans, ban.ChampionID)
+			t.Bans = append(t.Bans, Ban{ChampionID: ban.ChampionID, PickTurn: ban.PickTurn})
 		}
+
+		match.Teams = append(match.Teams, t)
 	}
 
 	return match
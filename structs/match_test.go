@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/anyweez/matchgrab/config"
@@ -23,6 +24,16 @@ func outcomes() map[RiotID]champStatus {
 	}
 }
 
+// decoded : Decode a record, failing the test if it can't be.
+func decoded(t *testing.T, buf []byte) *Match {
+	m, err := MakeMatch(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
 // Make sure we are encoding + decoding to storage format correctly.
 func TestToFromProto(t *testing.T) {
 	samples := rawSamples()
@@ -31,7 +42,7 @@ func TestToFromProto(t *testing.T) {
 		match := ToMatch(sample)
 
 		buf := match.Bytes()
-		m2 := MakeMatch(buf)
+		m2 := decoded(t, buf)
 
 		if match.GameID != m2.GameID {
 			t.Fail()
@@ -53,6 +64,67 @@ func TestToFromProto(t *testing.T) {
 	}
 }
 
+// Make sure team data is parsed and survives encoding.
+func TestTeams(t *testing.T) {
+	for _, sample := range rawSamples() {
+		if sample.GameID != 2546243495 {
+			continue
+		}
+
+		match := decoded(t, ToMatch(sample).Bytes())
+
+		if len(match.Teams) != 2 || match.Team(RedTeam) == nil {
+			t.Fatalf("expected both teams, got %d", len(match.Teams))
+		}
+
+		blue := match.Team(BlueTeam)
+		if !blue.Winner || match.Team(RedTeam).Winner {
+			t.Error("wrong winner")
+		}
+
+		if !blue.FirstBaron || blue.FirstRiftHerald || blue.TowerKills != 11 || blue.DragonKills != 5 {
+			t.Errorf("unexpected objectives: %+v", blue)
+		}
+
+		if len(blue.Bans) != 5 || blue.Bans[0].ChampionID != 122 || blue.Bans[4].PickTurn != 5 {
+			t.Errorf("unexpected bans: %+v", blue.Bans)
+		}
+
+		return
+	}
+
+	t.Fatal("sample not found")
+}
+
+// Make sure the game version, queue and platform are parsed and survive encoding.
+func TestVersionFields(t *testing.T) {
+	for _, sample := range rawSamples() {
+		match := decoded(t, ToMatch(sample).Bytes())
+
+		if match.GameVersion == "" || match.GameVersion != sample.GameVersion {
+			t.Errorf("game version %q, expected %q", match.GameVersion, sample.GameVersion)
+		}
+
+		if match.QueueID == 0 || match.QueueID != sample.QueueID {
+			t.Errorf("queue %d, expected %d", match.QueueID, sample.QueueID)
+		}
+
+		if match.PlatformID != sample.PlatformID {
+			t.Errorf("platform %s, expected %s", match.PlatformID, sample.PlatformID)
+		}
+	}
+
+	m := Match{GameVersion: "7.10.187.9675"}
+	if m.Patch() != "7.10" {
+		t.Errorf("patch %s", m.Patch())
+	}
+
+	m.GameVersion = ""
+	if m.Patch() != "" {
+		t.Error("patch for a match without a version")
+	}
+}
+
 // TODO: save some raw json match data for next tests
 
 // Ensure we correctly parse raw API responses into Match's.
@@ -143,8 +215,75 @@ func TestNoStats(t *testing.T) {
 	}
 }
 
+// Make sure perks are parsed and survive encoding, and that older matches don't have any.
+func TestPerks(t *testing.T) {
+	config.Setup()
+	config.Config.KeepStats = true
+
+	raw := APIMatch{}
+	err := json.Unmarshal([]byte(`{
+		"gameId": 1,
+		"participants": [{"stats": {
+			"perkPrimaryStyle": 8100, "perkSubStyle": 8300,
+			"perk0": 8112, "perk0Var1": 1200, "perk0Var2": 3, "perk0Var3": 0,
+			"perk1": 8139, "perk2": 8138, "perk3": 8135, "perk4": 8345, "perk5": 8347, "perk5Var3": 7
+		}}],
+		"participantIdentities": [{"player": {"accountId": 2}}]
+	}`), &raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats := decoded(t, ToMatch(raw).Bytes()).Participants[0].Stats
+
+	if stats.PerkPrimaryStyle != 8100 || stats.PerkSubStyle != 8300 || len(stats.Perks) != 6 {
+		t.Fatalf("unexpected perks: %+v", stats)
+	}
+
+	if stats.Perks[0] != (Perk{ID: 8112, Var1: 1200, Var2: 3}) || stats.Perks[5].Var3 != 7 {
+		t.Errorf("unexpected perks: %+v", stats.Perks)
+	}
+
+	for _, sample := range rawSamples() {
+		for _, p := range decoded(t, ToMatch(sample).Bytes()).Participants {
+			if len(p.Stats.Perks) != 0 {
+				t.Error("match from before Runes Reforged has perks")
+			}
+		}
+	}
+}
+
+// Make sure positions, spells, and tiers are kept even without stats.
+func TestPositions(t *testing.T) {
+	config.Setup()
+	config.Config.KeepStats = false
+
+	for _, sample := range rawSamples() {
+		if sample.GameID != 2546243495 {
+			continue
+		}
+
+		p := decoded(t, ToMatch(sample).Bytes()).Participants[0]
+
+		if p.Lane != "JUNGLE" || p.Role != "NONE" {
+			t.Errorf("unexpected position %s %s", p.Lane, p.Role)
+		}
+
+		if p.Spell1 != 4 || p.Spell2 != 12 || p.HighestAchievedSeasonTier != "SILVER" {
+			t.Errorf("unexpected participant: %+v", p)
+		}
+
+		return
+	}
+
+	t.Fatal("sample not found")
+}
+
 func TestPackStats(t *testing.T) {
-	cp := NewRiotChampPack()
+	cp, err := NewRiotChampPack("7.15.1")
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	for _, r := range rawSamples() {
 		packed := ToMatch(r)
@@ -421,7 +560,7 @@ func TestMatchToProto(t *testing.T) {
 
 	for _, raw := range raws {
 		before := ToMatch(raw)
-		after := MakeMatch(before.Bytes())
+		after := decoded(t, before.Bytes())
 
 		// Match fields
 		if before.GameID != after.GameID {
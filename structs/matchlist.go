@@ -1,8 +1,12 @@
 package structs
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"sync"
 	"time"
@@ -14,19 +18,43 @@ const (
 	MaxIDListSize = 100000
 	BloomGrowBy   = 2     // double in size on each grow()
 	BloomFpRate   = 0.001 // Increasing means more duplicate requests, decreasing means bloom filter consumes more memory.
+
+	// Spilled items are paged back into memory in batches once the in-memory queue is less
+	// than half full.
+	spillBatchSize = 10000
+)
+
+// Ways an IDList can track which items it has already seen; see IDListStats.Mode.
+const (
+	DedupBloom = "bloom" // in memory, but rejects a small fraction of new items
+	DedupExact = "exact" // on disk and never rejects new items; see ExactIn()
 )
 
 // IDList : A queue-like data structure that only allows items to be added once; if an item
 // has already been added then attempts to re-add it will be rejected. IDLists are safe to
 // use concurrently.
+//
+// Up to MaxIDListSize items are kept in memory. Once a list is given somewhere to spill to
+// (see SpillTo()), everything past that is written to disk and paged back in as the
+// in-memory queue drains, so the list can hold as many items as the disk allows. Without
+// somewhere to spill, Add() rejects new items once the queue is full.
+//
+// By default IDLists use a bloom filter to remember which items they've seen, which means
+// a small fraction of new items (see BloomFpRate) are rejected as duplicates. Lists that
+// need to be exact can keep a record of every item on disk instead; see ExactIn().
 type IDList struct {
 	Queue chan RiotID
 
-	blacklist   *bloom.BloomFilter
+	// Bloom filters can't be resized, so each grow() adds a new filter and items are checked
+	// against all of them. New items are always added to the last one.
+	blacklist   []*bloom.BloomFilter
 	blcap       uint       // Maximum amount of records blacklist can hold
 	blacklisted uint       // Total number of values that have been blacklisted
 	lockIDs     sync.Mutex // Concurrency mutexes
 
+	spill *spillQueue // overflow; nil unless SpillTo() has been called
+	exact *exactSet   // replaces the bloom filter once ExactIn() has been called
+
 	_growCount int // [debugging] count number of grow() calls
 }
 
@@ -37,76 +65,204 @@ func NewIDList() *IDList {
 	ml := IDList{
 		Queue:       make(chan RiotID, MaxIDListSize),
 		blacklisted: 0,
-		blacklist:   bloom.NewWithEstimates(blsize, BloomFpRate),
+		blacklist:   []*bloom.BloomFilter{bloom.NewWithEstimates(blsize, BloomFpRate)},
 		blcap:       blsize,
 	}
 
 	return &ml
 }
 
+// SpillTo : Store items that don't fit in memory in a LevelDB database at the specified path.
+// Items left in the database by a previous run are queued after everything in memory. Call
+// Close() to release the database.
+func (ml *IDList) SpillTo(path string) error {
+	sq, err := openSpillQueue(path)
+	if err != nil {
+		return err
+	}
+
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	if ml.spill != nil {
+		ml.spill.close()
+	}
+	ml.spill = sq
+
+	return nil
+}
+
+// ExactIn : Keep an exact record of every item in a LevelDB database at the specified path
+// instead of using the bloom filter. Items recorded by a previous run are kept, but anything
+// only recorded in the bloom filter is forgotten, so this should be called before anything
+// is added. Call Close() to release the database.
+func (ml *IDList) ExactIn(path string) error {
+	es, err := openExactSet(path)
+	if err != nil {
+		return err
+	}
+
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	if ml.exact != nil {
+		ml.exact.close()
+	}
+	ml.exact = es
+	ml.blacklisted = uint(es.count)
+
+	return nil
+}
+
+// Close : Release the spill and exact databases, if there are any. Their contents stay on
+// disk and are picked up by the next call to SpillTo() or ExactIn() with the same path. The
+// list keeps working in memory only after it's closed, though it won't remember anything
+// that was only recorded in the exact database.
+func (ml *IDList) Close() error {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	var err error
+
+	if ml.spill != nil {
+		err = ml.spill.close()
+		ml.spill = nil
+	}
+
+	if ml.exact != nil {
+		if exactErr := ml.exact.close(); err == nil {
+			err = exactErr
+		}
+		ml.exact = nil
+	}
+
+	return err
+}
+
 // Add : Add a new item to the list if it hasn't been added before. Items are
 // added in order and cannot be added to the same list twice.
 // Note that `IDList` uses a bloom filter to track which elements have been
 // added and some false positives occur, meaning that some items will be
 // incorrectly blocked.
 func (ml *IDList) Add(m RiotID) bool {
-	if !ml.Blacklisted(m) {
-		// Try to add it to the queue; if it doesn't work then skip
-		select {
-		case ml.Queue <- m:
-			ml.Blacklist(m)
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
 
-			return true
-		default:
+	if ml.test(m) {
+		return false
+	}
+
+	return ml.push(m)
+}
+
+// Requeue : Add an item to the end of the list even if it's been added before, i.e. to visit
+// it again. Returns false if there's no room for it.
+func (ml *IDList) Requeue(m RiotID) bool {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	return ml.push(m)
+}
+
+// push : Queue an item and blacklist it. MUST hold lockIDs.
+func (ml *IDList) push(m RiotID) bool {
+	// Once anything has spilled, new items have to go after it to keep them in order.
+	if ml.spill != nil && (ml.spill.len() > 0 || len(ml.Queue) == cap(ml.Queue)) {
+		if err := ml.spill.push(m); err != nil {
+			log.Println("Couldn't spill ID: " + err.Error())
 			return false
 		}
+
+		ml.addBlacklist(m)
+		return true
 	}
 
-	return false
+	// Try to add it to the queue; if it doesn't work then skip
+	select {
+	case ml.Queue <- m:
+		ml.addBlacklist(m)
+
+		return true
+	default:
+		return false
+	}
 }
 
 // Blacklisted : Returns a boolean indicating whether the specified ID exists in
 // the list. Note that `IDList` uses a bloom filter to track which elements have
 // been added so some false positives will occur.
 func (ml *IDList) Blacklisted(m RiotID) bool {
-	return ml.blacklist.Test(m.Bytes())
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	return ml.test(m)
 }
 
 // Blacklist : Add a new item to the blacklist. This is automatically called by
 // Add() and shouldn't be called externally unless you want to blacklist *without*
 // adding to the list.
 func (ml *IDList) Blacklist(m RiotID) {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	ml.addBlacklist(m)
+}
+
+// test : Check whether an item has been seen. MUST hold lockIDs.
+func (ml *IDList) test(m RiotID) bool {
+	if ml.exact != nil {
+		return ml.exact.has(m)
+	}
+
+	key := m.Bytes()
+
+	for _, filter := range ml.blacklist {
+		if filter.Test(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addBlacklist : Add an item to the bloom filter, growing it if needed. MUST hold lockIDs.
+func (ml *IDList) addBlacklist(m RiotID) {
+	if ml.exact != nil {
+		if !ml.exact.has(m) {
+			if err := ml.exact.add(m); err != nil {
+				log.Println("Couldn't record ID: " + err.Error())
+				return
+			}
+
+			ml.blacklisted++
+		}
+
+		return
+	}
+
 	// Check to make sure it isn't blacklisted (primarily to keep the
 	// count accurate).
-	if !ml.Blacklisted(m) {
-		ml.lockIDs.Lock()
-
+	if !ml.test(m) {
 		// Grow before we add if we're at capacity
 		if ml.blacklisted >= ml.blcap {
-			ml.grow() // MUST hold lockIDs lock
+			ml.grow()
 		}
 
 		// Add byte buffer to blacklist.
-		ml.blacklist.Add(m.Bytes())
+		ml.blacklist[len(ml.blacklist)-1].Add(m.Bytes())
 
 		ml.blacklisted++
-		ml.lockIDs.Unlock()
 	}
 }
 
-// grow : Increase the capacity of the bloom filter.
+// grow : Increase the capacity of the blacklist by adding another bloom filter big enough
+// to hold everything added until the next grow(). MUST hold lockIDs.
 func (ml *IDList) grow() {
 	log.Println(fmt.Sprintf("growing @ %s", time.Now()))
-	// Old blacklist; outgrown
-	retired := ml.blacklist
 
-	// Replace with a new blacklist struct that's bigger
-	ml.blcap = ml.blcap * BloomGrowBy
-	ml.blacklist = bloom.NewWithEstimates(ml.blcap, BloomFpRate)
-
-	// Merge in old data so that we don't lose any data.
-	// Side note: figure out how this works.
-	ml.blacklist.Merge(retired)
+	added := ml.blcap * (BloomGrowBy - 1)
+	ml.blacklist = append(ml.blacklist, bloom.NewWithEstimates(added, BloomFpRate))
+	ml.blcap += added
 
 	ml._growCount++
 }
@@ -114,35 +270,156 @@ func (ml *IDList) grow() {
 // Next : Get the next item from the list if anything is available. The second
 // return value will be true whenever an actual value is returned and false otherwise.
 func (ml *IDList) Next() (RiotID, bool) {
-	if len(ml.Queue) > 0 {
-		return <-ml.Queue, true
+	ml.refill()
+
+	// Don't block if another goroutine takes the last item first.
+	select {
+	case id := <-ml.Queue:
+		return id, true
+	default:
+		return -1, false
+	}
+}
+
+// refill : Page spilled items back into memory once the queue is half empty.
+func (ml *IDList) refill() {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	if ml.spill == nil || ml.spill.len() == 0 || len(ml.Queue) > cap(ml.Queue)/2 {
+		return
 	}
 
-	return -1, false
+	room := cap(ml.Queue) - len(ml.Queue)
+	if room > spillBatchSize {
+		room = spillBatchSize
+	}
+
+	ids, err := ml.spill.pop(room)
+	if err != nil {
+		log.Println("Couldn't read spilled IDs: " + err.Error())
+		return
+	}
+
+	// Only Add() and refill() put items in the queue and both hold lockIDs, so there's
+	// always room.
+	for _, id := range ids {
+		ml.Queue <- id
+	}
 }
 
 // Available : Returns true if there are any items in the list.
 func (ml *IDList) Available() bool {
-	return len(ml.Queue) > 0
+	return ml.Len() > 0
 }
 
-// Filled : Returns the percentage of the list capacity that's filled
+// Len : Returns the number of items waiting in the list, including spilled items.
+func (ml *IDList) Len() int {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	if ml.spill != nil {
+		return len(ml.Queue) + int(ml.spill.len())
+	}
+
+	return len(ml.Queue)
+}
+
+// Seen : Returns the number of distinct items that have ever been added or blacklisted.
+func (ml *IDList) Seen() int {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	return int(ml.blacklisted)
+}
+
+// IDListStats : Describes the state of an IDList. Bloom filter fields are zero in exact mode.
+type IDListStats struct {
+	Mode    string // DedupBloom or DedupExact
+	Seen    int    // distinct items added or blacklisted
+	Queued  int    // items in memory
+	Spilled int    // items waiting on disk
+
+	Filters           int     // number of bloom filters; one more is added on each grow()
+	GrowCount         int     // number of times grow() has been called since the list was created
+	Capacity          int     // items the bloom filters can hold before the next grow()
+	FalsePositiveRate float64 // estimated chance that a new item is rejected
+	MemoryBytes       int     // approximate memory used by the bloom filters and queue
+}
+
+// Stats : Returns information about the list, including how likely it is to reject new items.
+func (ml *IDList) Stats() IDListStats {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	stats := IDListStats{
+		Mode:        DedupBloom,
+		Seen:        int(ml.blacklisted),
+		Queued:      len(ml.Queue),
+		MemoryBytes: cap(ml.Queue) * len(RiotID(0).Bytes()),
+	}
+
+	if ml.spill != nil {
+		stats.Spilled = int(ml.spill.len())
+	}
+
+	if ml.exact != nil {
+		stats.Mode = DedupExact
+		return stats
+	}
+
+	stats.Filters = len(ml.blacklist)
+	stats.GrowCount = ml._growCount
+	stats.Capacity = int(ml.blcap)
+
+	// Every filter but the last was filled to capacity before grow() was called. Capacities
+	// follow from grow(): the first filter holds blcap / BloomGrowBy^(n-1) items and each new
+	// one brings the total up by a factor of BloomGrowBy.
+	capacity := float64(ml.blcap) / math.Pow(BloomGrowBy, float64(len(ml.blacklist)-1))
+	total := capacity
+	remaining := float64(ml.blacklisted)
+	passRate := 1.0
+
+	for i, filter := range ml.blacklist {
+		if i > 0 {
+			capacity = total * (BloomGrowBy - 1)
+			total += capacity
+		}
+
+		items := math.Min(capacity, remaining)
+		if i == len(ml.blacklist)-1 {
+			items = remaining
+		}
+		remaining -= items
+
+		m := float64(filter.Cap())
+		k := float64(filter.K())
+
+		// Chance that all k bits for a new item are already set.
+		passRate *= 1 - math.Pow(1-math.Exp(-k*items/m), k)
+		stats.MemoryBytes += int(filter.Cap() / 8)
+	}
+
+	stats.FalsePositiveRate = 1 - passRate
+
+	return stats
+}
+
+// Filled : Returns the percentage of the in-memory queue capacity that's filled
 func (ml *IDList) Filled() float32 {
 	return (float32(len(ml.Queue)) / float32(MaxIDListSize))
 }
 
 // Shuffle : Randomly distributes all items currently in the queue. Note that
 // this only applies to items *currently in the queue* and will not affect
-// insertion order for new items.
+// insertion order for new items or items that have been spilled to disk.
 func (ml *IDList) Shuffle() {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
 	rand.Seed(time.Now().UnixNano())
 
-	ids := make([]RiotID, len(ml.Queue))
-
-	// Copy data out of the channel
-	for i := 0; i < len(ids); i++ {
-		ids[i] = <-ml.Queue
-	}
+	ids := ml.drain()
 
 	// Shuffle data
 	for here, _ := range ids {
@@ -156,3 +433,113 @@ func (ml *IDList) Shuffle() {
 		ml.Queue <- ids[i]
 	}
 }
+
+// drain : Remove everything from the queue. Items can still be taken by Next() while this is
+// running, but nothing can be added. MUST hold lockIDs.
+func (ml *IDList) drain() []RiotID {
+	ids := make([]RiotID, 0, len(ml.Queue))
+
+	for {
+		select {
+		case id := <-ml.Queue:
+			ids = append(ids, id)
+		default:
+			return ids
+		}
+	}
+}
+
+// Format version for Bytes(); increase if the encoding changes. Version 1 only had a single
+// bloom filter.
+const idListVersion = 2
+
+// Bytes : Encode the in-memory queue and the blacklist so that the list can be restored with
+// MakeIDList(). Spilled items aren't included since they're already on disk. The queue is
+// left unchanged.
+func (ml *IDList) Bytes() []byte {
+	ml.lockIDs.Lock()
+	defer ml.lockIDs.Unlock()
+
+	ids := ml.drain()
+	for _, id := range ids {
+		ml.Queue <- id
+	}
+
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.BigEndian, []uint64{
+		idListVersion,
+		uint64(ml.blcap),
+		uint64(ml.blacklisted),
+		uint64(len(ids)),
+		uint64(len(ml.blacklist)),
+	})
+	binary.Write(buf, binary.BigEndian, ids)
+
+	for _, filter := range ml.blacklist {
+		filter.WriteTo(buf)
+	}
+
+	return buf.Bytes()
+}
+
+// MakeIDList : Restore an IDList encoded with Bytes(). Call SpillTo() afterwards to pick up
+// any spilled items.
+func MakeIDList(buf []byte) (*IDList, error) {
+	r := bytes.NewReader(buf)
+
+	var version uint64
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return nil, err
+	}
+
+	if version != 1 && version != idListVersion {
+		return nil, fmt.Errorf("unknown IDList version %d", version)
+	}
+
+	// blcap, blacklisted, queue length, and (since version 2) number of filters
+	header := make([]uint64, 4)
+	if version == 1 {
+		header = header[:3]
+	}
+
+	if err := binary.Read(r, binary.BigEndian, header); err != nil {
+		return nil, err
+	}
+
+	if version == 1 {
+		header = append(header, 1)
+	}
+
+	if header[2] > MaxIDListSize {
+		return nil, errors.New("IDList queue is larger than MaxIDListSize")
+	}
+
+	// Each filter doubles capacity so there should never be more than a few dozen.
+	if header[3] == 0 || header[3] > 64 {
+		return nil, fmt.Errorf("IDList has an invalid number of filters (%d)", header[3])
+	}
+
+	ids := make([]RiotID, header[2])
+	if err := binary.Read(r, binary.BigEndian, ids); err != nil {
+		return nil, err
+	}
+
+	ml := NewIDList()
+	ml.blcap = uint(header[0])
+	ml.blacklisted = uint(header[1])
+	ml.blacklist = make([]*bloom.BloomFilter, header[3])
+
+	for i := range ml.blacklist {
+		ml.blacklist[i] = &bloom.BloomFilter{}
+
+		if _, err := ml.blacklist[i].ReadFrom(r); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range ids {
+		ml.Queue <- id
+	}
+
+	return ml, nil
+}
@@ -2,6 +2,8 @@ package structs
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 )
 
@@ -76,6 +78,7 @@ func TestGrowTrigger(t *testing.T) {
 
 	failIf(idl._growCount != 1, t, fmt.Sprintf("_growCount = %d", idl._growCount))
 	failIf(idl.blacklisted != addCount, t, "incorrect blacklist count")
+	failIf(!idl.Blacklisted(RiotID(0)), t, "grow() forgot items added before it")
 }
 
 // Ensure that grow() operations are non-destructive and keep track of
@@ -90,3 +93,147 @@ func TestGrowMerge(t *testing.T) {
 		failIf(idl.blacklisted != 1, t, "incorrect number of items blacklisted")
 	}
 }
+
+// Ensure an encoded list comes back with the same queue and blacklist.
+func TestIDListBytes(t *testing.T) {
+	idl := NewIDList()
+
+	var i RiotID
+	for i = 0; i < 100; i++ {
+		idl.Add(i)
+	}
+	for i = 0; i < 10; i++ {
+		idl.Next()
+	}
+
+	restored, err := MakeIDList(idl.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failIf(restored.Seen() != 100, t, "blacklist count wasn't restored")
+	failIf(!restored.Blacklisted(5), t, "dequeued item wasn't blacklisted")
+	failIf(restored.Add(50), t, "queued item was added twice")
+
+	for i = 10; i < 100; i++ {
+		id, _ := restored.Next()
+		failIf(id != i, t, fmt.Sprintf("expected %d, got %d", i, id))
+	}
+
+	// The original shouldn't have changed.
+	id, _ := idl.Next()
+	failIf(id != 10, t, "encoding changed the original queue")
+
+	_, err = MakeIDList([]byte("garbage"))
+	failIf(err == nil, t, "decoded garbage")
+}
+
+// Ensure items past MaxIDListSize are spilled to disk and come back in order.
+func TestSpill(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	idl := NewIDList()
+	if err := idl.SpillTo(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	total := MaxIDListSize + 500
+	for i := 0; i < total; i++ {
+		failIf(!idl.Add(RiotID(i)), t, fmt.Sprintf("%d was dropped", i))
+	}
+
+	failIf(idl.Len() != total, t, fmt.Sprintf("expected %d items, found %d", total, idl.Len()))
+	failIf(idl.Add(RiotID(total-1)), t, "spilled item was added twice")
+
+	for i := 0; i < total; i++ {
+		id, ok := idl.Next()
+		if !ok || id != RiotID(i) {
+			t.Fatalf("expected %d, got %d", i, id)
+		}
+	}
+
+	failIf(idl.Available(), t, "items still available")
+	idl.Close()
+}
+
+// Ensure spilled items are still there after the list is closed and reopened.
+func TestSpillReopen(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	idl := NewIDList()
+	idl.SpillTo(dir)
+
+	for i := 0; i < MaxIDListSize+10; i++ {
+		idl.Add(RiotID(i))
+	}
+
+	restored, _ := MakeIDList(idl.Bytes())
+	idl.Close()
+
+	if err := restored.SpillTo(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	failIf(restored.Len() != MaxIDListSize+10, t, fmt.Sprintf("found %d items", restored.Len()))
+}
+
+// Ensure exact mode never rejects new items and remembers items across reopens.
+func TestExact(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	idl := NewIDList()
+	if err := idl.ExactIn(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		failIf(!idl.Add(RiotID(i)), t, fmt.Sprintf("%d was rejected", i))
+	}
+
+	failIf(idl.Add(RiotID(10)), t, "item was added twice")
+	failIf(idl.Blacklisted(RiotID(1000)), t, "unseen item is blacklisted")
+	failIf(idl.Seen() != 1000, t, fmt.Sprintf("seen = %d", idl.Seen()))
+	idl.Close()
+
+	reopened := NewIDList()
+	reopened.ExactIn(dir)
+	defer reopened.Close()
+
+	failIf(!reopened.Blacklisted(RiotID(999)), t, "forgot items after reopening")
+	failIf(reopened.Seen() != 1000, t, "count wasn't restored")
+	failIf(reopened.Stats().Mode != DedupExact, t, "wrong mode")
+}
+
+// Ensure bloom filter stats track the expected false positive rate across grows.
+func TestStats(t *testing.T) {
+	idl := NewIDList()
+
+	stats := idl.Stats()
+	failIf(stats.Mode != DedupBloom || stats.Filters != 1, t, "unexpected stats for new list")
+	failIf(stats.FalsePositiveRate != 0, t, "empty list has false positives")
+	failIf(stats.MemoryBytes < int(idl.blacklist[0].Cap()/8), t, "memory doesn't include bloom filter")
+
+	// Some items are false positives, so keep going until the filter is actually full.
+	next := 0
+	for idl.blacklisted < idl.blcap {
+		idl.Blacklist(RiotID(next))
+		next++
+	}
+
+	// A full filter should be right around its target rate.
+	full := idl.Stats().FalsePositiveRate
+	failIf(full < BloomFpRate/2 || full > BloomFpRate*2, t, fmt.Sprintf("fp rate = %f", full))
+
+	for idl.blacklisted == idl.blcap {
+		idl.Blacklist(RiotID(next))
+		next++
+	}
+
+	stats = idl.Stats()
+	failIf(stats.Filters != 2 || stats.GrowCount != 1, t, "grow wasn't reported")
+	failIf(stats.FalsePositiveRate < full, t, "adding a filter lowered the fp rate")
+}
@@ -1,130 +1,235 @@
 package structs
 
 import (
+	"log"
 	"sync"
 	"time"
 
+	"github.com/anyweez/matchgrab/config"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 )
 
 const (
-	copyInterval   = 1 * time.Hour
 	SnapshotSuffix = "-snapshot"
+
+	// LegacyPlatform : Platform assumed for records written before matches were keyed by
+	// platform. These records are keyed by GameID alone.
+	LegacyPlatform = "NA1"
 )
 
+// MatchKey : Returns the LevelDB key for a match. Game ID's are only unique within a
+// platform, so keys are the platform ID followed by the big-endian GameID.
+//
+// Everything else kept in the store (dead letters, etc) uses a lowercase prefix so that it
+// never collides with match keys; see isMatchKey().
+func MatchKey(platform string, id RiotID) []byte {
+	return append([]byte(platform), id.Bytes()...)
+}
+
+// isMatchKey : Returns true if the key belongs to a match record. Match keys are either a bare
+// GameID (legacy records) or start with an uppercase platform ID.
+func isMatchKey(key []byte) bool {
+	if len(key) == len(RiotID(0).Bytes()) {
+		return true
+	}
+
+	return len(key) > 0 && key[0] >= 'A' && key[0] <= 'Z'
+}
+
 // MatchStore : Represents a persistent data store for match data. Implements a thin layer over
 // a LevelDB instance and is capable of reading and writing match data to the database. All
 // writes are serialized and its therefore safe to call `Add()` from multiple goroutines.
+//
+// Matches are written in batches; call Flush() to make sure they've been written, and read
+// Errors() to find out about writes that failed.
 type MatchStore struct {
-	queue     chan Match
-	db        *leveldb.DB
-	count     int
-	countInit bool // becomes true if the count is accurate
-	active    bool // stop secondary goroutines when this becomes false
-
-	dbLock sync.Mutex
+	location string // path to the LevelDB directory
+	queue    chan queuedMatch
+	flushes  chan chan error
+	errs     chan error
+	db       *leveldb.DB
+	active   bool // stop secondary goroutines when this becomes false
+	closed   chan struct{}
+	readOnly bool // opened with OpenReadOnly(); there's no writer goroutine
+
+	// Snapshots are taken every snapshotInterval (never if zero), keeping the newest
+	// `generations` of them; see Snapshot().
+	snapshotInterval time.Duration
+	generations      int
+
+	meta        storeMeta // see Stats()
+	metrics     WriteMetrics
+	metricsLock sync.Mutex
+
+	dbLock sync.Mutex // held while taking a snapshot so the db isn't closed underneath it
 }
 
-// NewMatchStore : Create a new MatchStore that automatically records data and sync it
-// to a snapshot instance.
+// NewMatchStore : Create a new MatchStore that automatically records data and periodically
+// snapshots it, as configured by Config.SnapshotInterval and Config.SnapshotGenerations.
 func NewMatchStore(filename string) *MatchStore {
 	ms := makeMs(filename, true)
 
 	return ms
 }
 
+// OpenMatchStore : Open a MatchStore without taking snapshots, i.e. to read a snapshot or to
+// briefly open a store that isn't being crawled. Returns an error if the store is locked by
+// another process.
+func OpenMatchStore(filename string) (*MatchStore, error) {
+	return openMs(filename, false, false)
+}
+
+// OpenReadOnly : Open a MatchStore that can only be read, i.e. a snapshot generation. Files
+// aren't modified (not even by compaction) and nothing is written; Add() is ignored and Flush()
+// returns ErrReadOnly.
+func OpenReadOnly(filename string) (*MatchStore, error) {
+	return openMs(filename, false, true)
+}
+
 // makeMs : Internal method for creating a MatchStore with pre-populated defaults.
 func makeMs(filename string, makeSnapshot bool) *MatchStore {
+	ms, err := openMs(filename, makeSnapshot, false)
+
+	if err != nil {
+		panic("Cannot open LevelDB records: " + err.Error())
+	}
+
+	return ms
+}
+
+func openMs(filename string, makeSnapshot bool, readOnly bool) (*MatchStore, error) {
 	ms := &MatchStore{
-		queue:     make(chan Match, 10),
-		count:     0,
-		countInit: false,
-		active:    true,
+		location: filename,
+		queue:    make(chan queuedMatch, writeQueueSize),
+		flushes:  make(chan chan error),
+		errs:     make(chan error, 100),
+		active:   !readOnly,
+		closed:   make(chan struct{}),
+		readOnly: readOnly,
+
+		generations: config.Config.SnapshotGenerations,
+	}
+
+	if makeSnapshot {
+		ms.snapshotInterval = config.Config.SnapshotInterval
 	}
 
 	var err error
-	ms.db, err = leveldb.OpenFile(filename, nil)
+	ms.db, err = leveldb.OpenFile(filename, &opt.Options{ReadOnly: readOnly})
 
 	if err != nil {
-		panic("Cannot open LevelDB records: " + err.Error())
+		return nil, err
+	}
+
+	if err := ms.loadStats(); err != nil {
+		ms.db.Close()
+		return nil, err
+	}
+
+	if readOnly {
+		return ms, nil
 	}
 
 	// Goroutine that asynchronously writes match data until the matchstore is closed.
 	// Once MatchStore.Close() is called, this goroutine finishes writing all queued
 	// changes and then closes the database, releasing the lock.
-	go func() {
-		for m := range ms.queue {
-			err := ms.db.Put(m.GameID.Bytes(), m.Bytes(), nil)
-
-			if err != nil {
-				panic("Error writing record: " + err.Error())
-			}
-
-			ms.count++
-		}
-
-		ms.db.Close()
-	}()
+	go ms.writeLoop()
 
-	if makeSnapshot {
-		// Periodically copy all data over to a second database that can be accessed while
+	if ms.snapshotInterval > 0 {
+		// Periodically snapshot the store into a second database that can be accessed while
 		// new data is being downloaded to the primary.
 		go func() {
-			// Back up everything once per period (defined as const above).
-			for ms.active {
-				time.Sleep(copyInterval)
-
-				// Open, take snapshot, and then close. Keep the lock for as little time as possible.
-				backup := makeMs(filename+SnapshotSuffix, false)
-				ms.Each(func(m *Match) {
-					backup.Add(*m)
-				})
-				backup.Close()
+			ticker := time.NewTicker(ms.snapshotInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					if _, err := ms.Snapshot(); err != nil {
+						log.Println("Couldn't snapshot match store: " + err.Error())
+					}
+				case <-ms.closed:
+					return
+				}
 			}
 		}()
 	}
 
-	return ms
+	return ms, nil
 }
 
-// Count : Returns the total number of records written to disk. Inaccurate unless Each() has been
-// called at least once.
+// Location : Returns the path that the store was opened from.
+func (ms *MatchStore) Location() string {
+	return ms.location
+}
+
+// Count : Returns the total number of matches written to disk. See Stats() for more detail.
 func (ms *MatchStore) Count() int {
-	return ms.count
+	ms.meta.lock.Lock()
+	defer ms.meta.lock.Unlock()
+
+	return ms.meta.stats.Matches
 }
 
-// Add : Queue up a new match to be written asynchronously.
+// Add : Queue up a new match to be written asynchronously. Only blocks if writes have fallen
+// far behind; see Metrics().
 func (ms *MatchStore) Add(m Match) {
 	if ms.active {
-		ms.queue <- m
+		ms.queue <- queuedMatch{match: m, added: time.Now()}
 	}
 }
 
-func (ms *MatchStore) Get(id RiotID) (*Match, error) {
-	raw, err := ms.db.Get(id.Bytes(), nil)
+// Get : Retrieve a single match from the specified platform. Returns leveldb.ErrNotFound if it
+// isn't stored, or an error from MakeMatch() if the record can't be read.
+func (ms *MatchStore) Get(platform string, id RiotID) (*Match, error) {
+	raw, err := ms.db.Get(MatchKey(platform, id), nil)
 
-	return MakeMatch(raw), err
+	// Fall back to the pre-platform key format for records that haven't been migrated.
+	if err == leveldb.ErrNotFound && platform == LegacyPlatform {
+		raw, err = ms.db.Get(id.Bytes(), nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeMatch(raw)
 }
 
-// Each : Extract matches one by one.
+// Each : Extract matches one by one. Records that can't be read are logged and skipped.
 func (ms *MatchStore) Each(fn func(*Match)) {
 	iter := ms.db.NewIterator(nil, nil)
+	defer iter.Release()
 
 	for iter.Next() {
-		match := MakeMatch(iter.Value())
-		fn(match)
+		if !isMatchKey(iter.Key()) {
+			continue
+		}
 
-		if !ms.countInit {
-			ms.count++
+		match, err := MakeMatch(iter.Value())
+		if err != nil {
+			log.Printf("Skipping match record %x: %s", iter.Key(), err.Error())
+			continue
 		}
-	}
 
-	ms.countInit = true
+		fn(match)
+	}
 }
 
 // Close : Clean up all related resources. No reads or writes are allowed after this
-// function is called.
+// function is called. Returns once all queued matches have been written and the database
+// has been closed.
 func (ms *MatchStore) Close() {
+	if ms.readOnly {
+		ms.db.Close()
+		close(ms.errs)
+		close(ms.closed)
+		return
+	}
+
 	close(ms.queue) // triggers closing of db once queue is empty
 	ms.active = false
+
+	<-ms.closed
 }
@@ -62,3 +62,29 @@ func TestRetrieval(t *testing.T) {
     expectedNext++
   })
 }
+
+// Make sure the same GameID on two platforms doesn't collide.
+func TestPlatformKeys(t *testing.T) {
+  dir, _ := ioutil.TempDir("", "test")
+
+  defer os.RemoveAll(dir)
+  defer os.RemoveAll(dir + SnapshotSuffix)
+
+  store := NewMatchStore(dir)
+  defer store.Close()
+
+  store.Add(Match{GameID: RiotID(1), PlatformID: "NA1", GameDuration: 100})
+  store.Add(Match{GameID: RiotID(1), PlatformID: "EUW1", GameDuration: 200})
+
+  time.Sleep(1 * time.Second)
+
+  na, err := store.Get("NA1", RiotID(1))
+  if err != nil || na.GameDuration != 100 || na.PlatformID != "NA1" {
+    t.Fail()
+  }
+
+  euw, err := store.Get("EUW1", RiotID(1))
+  if err != nil || euw.GameDuration != 200 || euw.PlatformID != "EUW1" {
+    t.Fail()
+  }
+}
@@ -2,6 +2,20 @@ package structs
 
 // MatchSummary : summary information about matches from the API
 type MatchSummary struct {
-	GameID    RiotID
-	Timestamp int64
+	GameID     RiotID
+	PlatformID string
+	Timestamp  int64
+	Queue      int    // queue ID, same as Match.QueueID
+	Season     int    // season ID, same as Match.SeasonID
+	Champion   RiotID // champion the summoner played
+	Lane       string // i.e. TOP, JUNGLE, MIDDLE, BOTTOM
+	Role       string // i.e. SOLO, DUO_CARRY, DUO_SUPPORT, NONE
+}
+
+// Matchlist : One page of a summoner's matches, most recent first.
+type Matchlist struct {
+	Matches    []MatchSummary
+	StartIndex int
+	EndIndex   int
+	TotalGames int
 }
@@ -0,0 +1,296 @@
+package structs
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Metadata keys. Counts and versions are big-endian 64-bit integers and times are Unix
+// nanoseconds.
+const (
+	metaPrefix       = "meta:"
+	metaCount        = metaPrefix + "count"
+	metaSeasonPrefix = metaPrefix + "season:"
+	metaQueuePrefix  = metaPrefix + "queue:"
+	metaSchema       = metaPrefix + "schema"
+	metaCreated      = metaPrefix + "created"
+	metaLastWrite    = metaPrefix + "last_write"
+)
+
+// StoreStats : Exact counts of the matches in a store, along with when it was created and last
+// written to. Kept up to date in the same batch as each write; see MatchStore.Stats().
+type StoreStats struct {
+	Matches  int
+	BySeason map[int]int
+	ByQueue  map[int]int
+
+	SchemaVersion int // oldest schema any record may have been written with; see Migrate()
+	Created       time.Time
+	LastWrite     time.Time // zero if no matches have been written
+}
+
+// storeMeta : The in-memory copy of a store's StoreStats. The lock is held while matches are
+// written so counts are updated in the same order as the records.
+type storeMeta struct {
+	stats StoreStats
+	lock  sync.Mutex
+}
+
+func (s StoreStats) copy() StoreStats {
+	c := s
+	c.BySeason = make(map[int]int, len(s.BySeason))
+	c.ByQueue = make(map[int]int, len(s.ByQueue))
+
+	for season, count := range s.BySeason {
+		c.BySeason[season] = count
+	}
+	for queue, count := range s.ByQueue {
+		c.ByQueue[queue] = count
+	}
+
+	return c
+}
+
+func (s *StoreStats) count(m *Match, delta int) {
+	s.Matches += delta
+	s.BySeason[m.SeasonID] += delta
+	s.ByQueue[m.QueueID] += delta
+
+	if s.BySeason[m.SeasonID] == 0 {
+		delete(s.BySeason, m.SeasonID)
+	}
+	if s.ByQueue[m.QueueID] == 0 {
+		delete(s.ByQueue, m.QueueID)
+	}
+}
+
+// Stats : Returns exact counts and metadata for the store.
+func (ms *MatchStore) Stats() StoreStats {
+	ms.meta.lock.Lock()
+	defer ms.meta.lock.Unlock()
+
+	return ms.meta.stats.copy()
+}
+
+// putMatches : Write matches with their index entries and updated counts in a single batch.
+// Matches that replace an existing record only change the counts if their season or queue
+// changed, and the replaced record's index entries are removed. `write` is called with the
+// finished batch.
+func (ms *MatchStore) putMatches(matches []Match, write func(*leveldb.Batch) error) error {
+	ms.meta.lock.Lock()
+	defer ms.meta.lock.Unlock()
+
+	next := ms.meta.stats.copy()
+	batch := new(leveldb.Batch)
+	written := make(map[string]*Match, len(matches))
+
+	for i := range matches {
+		m := &matches[i]
+		key := string(m.Key())
+
+		previous, exists := written[key]
+		if !exists {
+			// Records that can't be decoded were never counted.
+			if raw, err := ms.db.Get(m.Key(), nil); err == nil {
+				previous, _ = MakeMatch(raw)
+			} else if err != leveldb.ErrNotFound {
+				return err
+			}
+		}
+
+		// Entries for values that changed (i.e. a different champion) would otherwise be left
+		// pointing at this match.
+		if previous != nil {
+			next.count(previous, -1)
+			removeIndexes(batch, previous)
+		}
+		next.count(m, 1)
+		written[key] = m
+
+		addMatch(batch, m)
+	}
+
+	next.LastWrite = time.Now()
+	putStats(batch, ms.meta.stats, next)
+
+	if err := write(batch); err != nil {
+		return err
+	}
+
+	ms.meta.stats = next
+	return nil
+}
+
+// putStats : Add the keys that changed between two versions of the stats to a batch.
+func putStats(batch *leveldb.Batch, prev StoreStats, next StoreStats) {
+	if next.Matches != prev.Matches {
+		batch.Put([]byte(metaCount), encodeInt(int64(next.Matches)))
+	}
+
+	putCounts(batch, metaSeasonPrefix, prev.BySeason, next.BySeason)
+	putCounts(batch, metaQueuePrefix, prev.ByQueue, next.ByQueue)
+
+	if next.SchemaVersion != prev.SchemaVersion {
+		batch.Put([]byte(metaSchema), encodeInt(int64(next.SchemaVersion)))
+	}
+	if !next.Created.Equal(prev.Created) {
+		batch.Put([]byte(metaCreated), encodeInt(next.Created.UnixNano()))
+	}
+	if !next.LastWrite.Equal(prev.LastWrite) {
+		batch.Put([]byte(metaLastWrite), encodeInt(next.LastWrite.UnixNano()))
+	}
+}
+
+func putCounts(batch *leveldb.Batch, prefix string, prev map[int]int, next map[int]int) {
+	for value, count := range next {
+		if prev[value] != count {
+			batch.Put([]byte(prefix+strconv.Itoa(value)), encodeInt(int64(count)))
+		}
+	}
+
+	for value := range prev {
+		if _, exists := next[value]; !exists {
+			batch.Delete([]byte(prefix + strconv.Itoa(value)))
+		}
+	}
+}
+
+// loadStats : Read the store's metadata. Stores that don't have any yet (new stores, or ones
+// created before metadata was kept) are counted from scratch.
+func (ms *MatchStore) loadStats() error {
+	stats := StoreStats{
+		BySeason: make(map[int]int),
+		ByQueue:  make(map[int]int),
+	}
+
+	iter := ms.db.NewIterator(util.BytesPrefix([]byte(metaPrefix)), nil)
+	found := false
+
+	for iter.Next() {
+		key := string(iter.Key())
+		if len(iter.Value()) != 8 {
+			continue
+		}
+		value := decodeInt(iter.Value())
+
+		switch {
+		case key == metaCount:
+			stats.Matches = int(value)
+		case key == metaSchema:
+			stats.SchemaVersion = int(value)
+		case key == metaCreated:
+			stats.Created = time.Unix(0, value)
+			found = true
+		case key == metaLastWrite:
+			stats.LastWrite = time.Unix(0, value)
+		case strings.HasPrefix(key, metaSeasonPrefix):
+			if season, err := strconv.Atoi(strings.TrimPrefix(key, metaSeasonPrefix)); err == nil {
+				stats.BySeason[season] = int(value)
+			}
+		case strings.HasPrefix(key, metaQueuePrefix):
+			if queue, err := strconv.Atoi(strings.TrimPrefix(key, metaQueuePrefix)); err == nil {
+				stats.ByQueue[queue] = int(value)
+			}
+		}
+	}
+	iter.Release()
+
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	ms.meta.stats = stats
+
+	if found {
+		return nil
+	}
+
+	// Read-only stores are counted but the counts can't be written.
+	if ms.readOnly {
+		stats, err := ms.recount()
+		ms.meta.stats = stats
+		return err
+	}
+
+	return ms.RebuildStats()
+}
+
+// RebuildStats : Recount every match in the store and replace the stored counts. Only needed if
+// records were changed without going through the store; new stores are counted automatically.
+// Records that can't be decoded aren't counted.
+func (ms *MatchStore) RebuildStats() error {
+	ms.meta.lock.Lock()
+	defer ms.meta.lock.Unlock()
+
+	next, err := ms.recount()
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	putStats(batch, ms.meta.stats, next)
+
+	// Write everything for stores that didn't have metadata yet.
+	if ms.meta.stats.Created.IsZero() {
+		batch.Put([]byte(metaCount), encodeInt(int64(next.Matches)))
+	}
+
+	if err := ms.db.Write(batch, nil); err != nil {
+		return err
+	}
+
+	ms.meta.stats = next
+	return nil
+}
+
+// recount : Returns the store's stats with every match counted again. The caller must hold
+// meta.lock unless the store is still being opened.
+func (ms *MatchStore) recount() (StoreStats, error) {
+	next := ms.meta.stats.copy()
+	next.Matches = 0
+	next.BySeason = make(map[int]int)
+	next.ByQueue = make(map[int]int)
+
+	next.SchemaVersion = SchemaVersion
+
+	if next.Created.IsZero() {
+		next.Created = time.Now()
+	}
+
+	iter := ms.db.NewIterator(nil, nil)
+	for iter.Next() {
+		if !isMatchKey(iter.Key()) {
+			continue
+		}
+
+		m, version, err := decodeMatch(iter.Value())
+		if err != nil {
+			continue
+		}
+
+		next.count(m, 1)
+		if version < next.SchemaVersion {
+			next.SchemaVersion = version
+		}
+	}
+	iter.Release()
+
+	return next, iter.Error()
+}
+
+func encodeInt(value int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(value))
+
+	return buf
+}
+
+func decodeInt(buf []byte) int64 {
+	return int64(binary.BigEndian.Uint64(buf))
+}
@@ -0,0 +1,88 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// Make sure counts are exact, survive reopening, and don't double count overwritten matches.
+func TestStoreStats(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	before := time.Now()
+
+	store := NewMatchStore(dir)
+
+	stats := store.Stats()
+	if stats.Matches != 0 || stats.SchemaVersion != SchemaVersion || stats.Created.Before(before) || !stats.LastWrite.IsZero() {
+		t.Fatalf("unexpected stats for a new store: %+v", stats)
+	}
+
+	for i := 0; i < 10; i++ {
+		store.Add(Match{GameID: RiotID(i), PlatformID: "NA1", SeasonID: 9, QueueID: 420 + 20*(i%2)})
+	}
+
+	// Overwrite a match, once with the same queue and once in a different season.
+	store.Add(Match{GameID: RiotID(0), PlatformID: "NA1", SeasonID: 9, QueueID: 420})
+	store.Add(Match{GameID: RiotID(1), PlatformID: "NA1", SeasonID: 8, QueueID: 440})
+	store.Flush()
+
+	check := func(stats StoreStats) {
+		if stats.Matches != 10 || store.Count() != 10 {
+			t.Errorf("counted %d matches", stats.Matches)
+		}
+		if stats.BySeason[9] != 9 || stats.BySeason[8] != 1 {
+			t.Errorf("unexpected season counts: %v", stats.BySeason)
+		}
+		if stats.ByQueue[420] != 5 || stats.ByQueue[440] != 5 || len(stats.ByQueue) != 2 {
+			t.Errorf("unexpected queue counts: %v", stats.ByQueue)
+		}
+		if stats.LastWrite.Before(before) {
+			t.Errorf("last write %s", stats.LastWrite)
+		}
+	}
+
+	written := store.Stats()
+	check(written)
+	store.Close()
+
+	store = NewMatchStore(dir)
+	defer store.Close()
+
+	reopened := store.Stats()
+	check(reopened)
+
+	if !reopened.Created.Equal(written.Created) || !reopened.LastWrite.Equal(written.LastWrite) {
+		t.Errorf("times changed after reopening: %+v", reopened)
+	}
+
+	if err := store.RebuildStats(); err != nil {
+		t.Fatal(err)
+	}
+	check(store.Stats())
+}
+
+// Make sure stores created before metadata was kept are counted when they're opened.
+func TestStoreStatsUpgrade(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	store := NewMatchStore(dir)
+	for i := 0; i < 5; i++ {
+		m := Match{GameID: RiotID(i), SeasonID: 8}
+		store.db.Put(m.Key(), m.Bytes(), nil)
+	}
+	store.db.Delete([]byte(metaCreated), nil)
+	store.db.Delete([]byte(metaCount), nil)
+	store.Close()
+
+	store = NewMatchStore(dir)
+	defer store.Close()
+
+	if stats := store.Stats(); stats.Matches != 5 || stats.BySeason[8] != 5 || stats.Created.IsZero() {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
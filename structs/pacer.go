@@ -15,16 +15,53 @@ import (
 // to avoid this simply set the value to 1 at initialization.
 //
 // You can also pause execution for any period using the PauseFor() function.
+//
+// In addition to the fixed pace, a pacer can track any number of named rate limit buckets
+// (see SetLimits() and Acquire()). Each bucket has one or more windows, i.e. 20 requests per
+// second and 100 per two minutes, and Acquire() blocks until every window in the requested
+// buckets has room for another request.
 type Pacer struct {
 	// config options
 	requestsPerMinute       int
 	maxSimultaneousRequests int
 
-	next             chan bool
-	pausedFor        time.Duration
-	acceptingNewRuns bool // only false when Close() has been called
+	next      chan bool
+	pausedFor time.Duration // guarded by bucketLock
+	done      chan struct{} // closed by Close() to stop all goroutines
+	closeOnce sync.Once
 
 	queue chan func()
+
+	buckets    map[string]*rateBucket
+	bucketLock sync.Mutex
+}
+
+// RateLimit : A number of requests allowed within a window of time, i.e. 20 requests per
+// second. Also used to report how many requests have already been made in a window.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// rateBucket : A named set of rate limit windows, keyed by window length.
+type rateBucket struct {
+	windows map[time.Duration]*rateWindow
+}
+
+// rateWindow : Tracks the number of requests made since the window started. Windows start
+// with the first request made after the previous window expires.
+type rateWindow struct {
+	limit int
+	count int
+	start time.Time
+}
+
+// expire : Reset the window if its time has passed.
+func (w *rateWindow) expire(now time.Time, length time.Duration) {
+	if !w.start.IsZero() && now.Sub(w.start) >= length {
+		w.count = 0
+		w.start = time.Time{}
+	}
 }
 
 func NewPacer(rpm int, sim int) *Pacer {
@@ -33,10 +70,12 @@ func NewPacer(rpm int, sim int) *Pacer {
 		maxSimultaneousRequests: sim,
 
 		// sim:  make(chan bool, sim),
-		next:             make(chan bool),
-		queue:            make(chan func()),
-		pausedFor:        0, // not required, but explicit > implicit :)
-		acceptingNewRuns: true,
+		next:      make(chan bool),
+		queue:     make(chan func()),
+		pausedFor: 0, // not required, but explicit > implicit :)
+		done:      make(chan struct{}),
+
+		buckets: make(map[string]*rateBucket),
 	}
 
 	// Start pacing goroutine
@@ -44,15 +83,16 @@ func NewPacer(rpm int, sim int) *Pacer {
 		delay := int((60.0 / float32(p.requestsPerMinute)) * 1000)
 
 		for {
-			// Don't block; if the channel isn't empty, skip and wait.
-			if len(p.next) == 0 {
-				p.next <- true
+			// Wait for a worker to take the next slot, or stop once the pacer is closed.
+			select {
+			case p.next <- true:
+			case <-p.done:
+				return
 			}
 
 			// Don't call any new functions if a pause has been specified.
-			if p.pausedFor != 0 {
-				time.Sleep(p.pausedFor)
-				p.pausedFor = 0
+			if pause := p.takePause(); pause != 0 {
+				time.Sleep(pause)
 			} else {
 				time.Sleep(time.Duration(delay) * time.Millisecond)
 			}
@@ -64,10 +104,18 @@ func NewPacer(rpm int, sim int) *Pacer {
 		go func() {
 			// Wait for a task to do. Once we get it, block until allowed to proceed
 			// based on pacing rules.
-			for fn := range p.queue {
-
-				<-p.next
-				fn()
+			for {
+				select {
+				case fn := <-p.queue:
+					select {
+					case <-p.next:
+						fn()
+					case <-p.done:
+						return
+					}
+				case <-p.done:
+					return
+				}
 			}
 		}()
 	}
@@ -78,31 +126,171 @@ func NewPacer(rpm int, sim int) *Pacer {
 // PauseFor : Pauses the pacer and will not start any new executions until the specified duration
 // passes.
 func (p *Pacer) PauseFor(d time.Duration) {
+	p.bucketLock.Lock()
+	defer p.bucketLock.Unlock()
+
 	p.pausedFor = d
 }
 
+// takePause : Returns the pause requested by PauseFor() (zero if none) and clears it.
+func (p *Pacer) takePause() time.Duration {
+	p.bucketLock.Lock()
+	defer p.bucketLock.Unlock()
+
+	pause := p.pausedFor
+	p.pausedFor = 0
+
+	return pause
+}
+
+// bucket : Returns the bucket with the specified name, creating it if needed. MUST hold
+// bucketLock.
+func (p *Pacer) bucket(name string) *rateBucket {
+	b, exists := p.buckets[name]
+
+	if !exists {
+		b = &rateBucket{
+			windows: make(map[time.Duration]*rateWindow),
+		}
+		p.buckets[name] = b
+	}
+
+	return b
+}
+
+// SetLimits : Set the rate limits for the named bucket. Windows that aren't included in
+// `limits` are removed; existing windows keep their current counts.
+func (p *Pacer) SetLimits(name string, limits []RateLimit) {
+	p.bucketLock.Lock()
+	defer p.bucketLock.Unlock()
+
+	b := p.bucket(name)
+	windows := make(map[time.Duration]*rateWindow, len(limits))
+
+	for _, limit := range limits {
+		w, exists := b.windows[limit.Window]
+		if !exists {
+			w = &rateWindow{}
+		}
+
+		w.limit = limit.Requests
+		windows[limit.Window] = w
+	}
+
+	b.windows = windows
+}
+
+// SyncCounts : Update the named bucket with the number of requests the server says have been
+// made in each window. Counts are only ever increased since the server may not have seen
+// requests that are still in flight.
+func (p *Pacer) SyncCounts(name string, counts []RateLimit) {
+	p.bucketLock.Lock()
+	defer p.bucketLock.Unlock()
+
+	b := p.bucket(name)
+	now := time.Now()
+
+	for _, count := range counts {
+		w, exists := b.windows[count.Window]
+		if !exists {
+			continue
+		}
+
+		w.expire(now, count.Window)
+
+		if count.Requests > w.count {
+			w.count = count.Requests
+		}
+
+		if w.start.IsZero() {
+			w.start = now
+		}
+	}
+}
+
+// Acquire : Blocks until all of the named buckets have room for another request, and then
+// counts the request against each of them. Buckets without any limits never block.
+func (p *Pacer) Acquire(names ...string) {
+	for {
+		p.bucketLock.Lock()
+
+		now := time.Now()
+		wait := time.Duration(0)
+
+		for _, name := range names {
+			for length, w := range p.bucket(name).windows {
+				w.expire(now, length)
+
+				if w.count >= w.limit {
+					if remaining := w.start.Add(length).Sub(now); remaining > wait {
+						wait = remaining
+					}
+				}
+			}
+		}
+
+		// Room in every window; record the request.
+		if wait == 0 {
+			for _, name := range names {
+				for _, w := range p.bucket(name).windows {
+					if w.start.IsZero() {
+						w.start = now
+					}
+
+					w.count++
+				}
+			}
+
+			p.bucketLock.Unlock()
+			return
+		}
+
+		p.bucketLock.Unlock()
+		time.Sleep(wait)
+	}
+}
+
+// Wait : Blocks until the pace allows another request. Used by functions run by the pacer that
+// make more than one request, since each run only takes a single slot. Returns false if the pacer
+// is closed first.
+func (p *Pacer) Wait() bool {
+	select {
+	case <-p.next:
+		return true
+	case <-p.done:
+		return false
+	}
+}
+
 // Each : Runs the specific function as quickly as allowed w/ pacing rules. A pacer starts
 // each run on a separate goroutine (up to maxSimultaneousRequests at a time) so its
 // likely that multiple instances will be running at once if that's > 1.
 //
-// If count is zero, runs indefinitely.
+// If count is zero, runs until the pacer is closed. Run() always returns once Close() is
+// called, though runs that have already started may still be finishing.
 func (p *Pacer) Run(fn func(), count int) {
 	if count == 0 { // infinite
 		for {
-			if p.acceptingNewRuns {
-				p.queue <- fn
+			select {
+			case p.queue <- fn:
+			case <-p.done:
+				return
 			}
 		}
 	} else { // finite # of calls
 		var wg sync.WaitGroup
-		wg.Add(count)
 
 		for i := 0; i < count; i++ {
-			if p.acceptingNewRuns {
-				p.queue <- func() {
-					fn()
-					wg.Done()
-				}
+			wg.Add(1)
+
+			select {
+			case p.queue <- func() {
+				fn()
+				wg.Done()
+			}:
+			case <-p.done:
+				wg.Done()
+				return
 			}
 		}
 		// Wait for all runs to finish
@@ -110,9 +298,10 @@ func (p *Pacer) Run(fn func(), count int) {
 	}
 }
 
+// Close : Stop the pacer. No new runs are started after Close() is called and any calls to
+// Run() return. Safe to call more than once.
 func (p *Pacer) Close() {
-	p.acceptingNewRuns = false
-
-	close(p.next)
-	close(p.queue)
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
 }
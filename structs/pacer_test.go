@@ -87,16 +87,24 @@ func TestInfiniteRuns(t *testing.T) {
 		p.Run(func() {
 			count++
 
-			// Manually close after 100 executions
-			if count > 100 {
+			// Manually close after 100 executions. Runs continue in the background so
+			// only signal once.
+			if count == 101 {
 				wg.Done()
 			}
 		}, 0)
 	}()
 
 	// Test fails if we've been running for 15 seconds.
+	done := make(chan bool)
 	go func() {
 		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
 			if time.Now().Sub(start).Seconds() > 15.0 {
 				t.Fail()
 			}
@@ -106,6 +114,7 @@ func TestInfiniteRuns(t *testing.T) {
 	}()
 
 	wg.Wait()
+	close(done)
 
 	duration := time.Now().Sub(start).Seconds()
 
@@ -113,3 +122,83 @@ func TestInfiniteRuns(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// Acquire should block once a bucket's window is full and resume when it expires.
+// Runs that make a second request should wait for a second slot
+func TestWaitTakesSlot(t *testing.T) {
+	p := NewPacer(60, 1)
+
+	start := time.Now()
+	p.Run(func() {
+		if !p.Wait() {
+			t.Error("pacer closed")
+		}
+	}, 2)
+
+	duration := time.Now().Sub(start).Seconds()
+
+	if !closeEnough(duration, 3.0) {
+		t.Errorf("took %.1f seconds", duration)
+	}
+}
+
+func TestBucketLimits(t *testing.T) {
+	p := NewPacer(600, 1)
+	p.SetLimits("app", []RateLimit{
+		RateLimit{Requests: 2, Window: 2 * time.Second},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		p.Acquire("app", "method")
+	}
+
+	duration := time.Now().Sub(start).Seconds()
+
+	if !closeEnough(duration, 2.0) {
+		fmt.Println(duration)
+		t.Fail()
+	}
+}
+
+// Counts reported by the server should count against the bucket.
+func TestBucketSyncCounts(t *testing.T) {
+	p := NewPacer(600, 1)
+	p.SetLimits("app", []RateLimit{
+		RateLimit{Requests: 5, Window: 1 * time.Second},
+	})
+	p.SyncCounts("app", []RateLimit{
+		RateLimit{Requests: 5, Window: 1 * time.Second},
+	})
+
+	start := time.Now()
+	p.Acquire("app")
+
+	duration := time.Now().Sub(start).Seconds()
+
+	if !closeEnough(duration, 1.0) {
+		fmt.Println(duration)
+		t.Fail()
+	}
+}
+
+// TestCloseStopsRun : Ensure an infinite Run() returns once the pacer is closed.
+func TestCloseStopsRun(t *testing.T) {
+	p := NewPacer(6000, 2)
+	returned := make(chan bool)
+
+	go func() {
+		p.Run(func() {}, 0)
+		returned <- true
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	p.Close()
+	p.Close() // closing twice is fine
+
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fail()
+	}
+}
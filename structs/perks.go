@@ -0,0 +1,59 @@
+package structs
+
+import (
+	protostruct "github.com/anyweez/matchgrab/proto"
+)
+
+// Perk : A rune selected with Runes Reforged. What each variable means depends on the perk, i.e.
+// total damage dealt by Electrocute or healing done by Conqueror.
+type Perk struct {
+	ID   int32
+	Var1 int32
+	Var2 int32
+	Var3 int32
+}
+
+// selectedPerks : The API always returns six perk slots; drop the empty ones so that matches
+// from before Runes Reforged don't have any perks.
+func selectedPerks(slots []Perk) []Perk {
+	perks := make([]Perk, 0, len(slots))
+
+	for _, perk := range slots {
+		if perk.ID != 0 {
+			perks = append(perks, perk)
+		}
+	}
+
+	return perks
+}
+
+func perksToProto(perks []Perk) []*protostruct.Perk {
+	encoded := make([]*protostruct.Perk, 0, len(perks))
+
+	for _, perk := range perks {
+		encoded = append(encoded, &protostruct.Perk{
+			ID:   perk.ID,
+			Var1: perk.Var1,
+			Var2: perk.Var2,
+			Var3: perk.Var3,
+		})
+	}
+
+	return encoded
+}
+
+// makePerks : The inverse of perksToProto().
+func makePerks(encoded []*protostruct.Perk) []Perk {
+	perks := make([]Perk, 0, len(encoded))
+
+	for _, perk := range encoded {
+		perks = append(perks, Perk{
+			ID:   perk.GetID(),
+			Var1: perk.GetVar1(),
+			Var2: perk.GetVar2(),
+			Var3: perk.GetVar3(),
+		})
+	}
+
+	return perks
+}
@@ -0,0 +1,176 @@
+package structs
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+)
+
+// RetryQueue : Holds ID's whose requests failed so that they can be attempted again later.
+// Each failure doubles the amount of time before the ID becomes available again, and once an
+// ID has failed `maxAttempts` times it's dropped from the queue so that the caller can record
+// it somewhere else (see MatchStore.AddDeadLetter()). RetryQueues are safe to use concurrently.
+type RetryQueue struct {
+	maxAttempts int
+	backoff     time.Duration // wait after the first failure
+
+	attempts map[RiotID]int // failures so far for each ID that hasn't succeeded
+	schedule retrySchedule  // ID's waiting for their next attempt, earliest first
+	lock     sync.Mutex
+}
+
+type retryEntry struct {
+	id   RiotID
+	next time.Time
+}
+
+// retrySchedule : Min-heap of retry entries ordered by next attempt time.
+type retrySchedule []retryEntry
+
+func (rs retrySchedule) Len() int            { return len(rs) }
+func (rs retrySchedule) Less(i, j int) bool  { return rs[i].next.Before(rs[j].next) }
+func (rs retrySchedule) Swap(i, j int)       { rs[i], rs[j] = rs[j], rs[i] }
+func (rs *retrySchedule) Push(x interface{}) { *rs = append(*rs, x.(retryEntry)) }
+func (rs *retrySchedule) Pop() interface{} {
+	old := *rs
+	entry := old[len(old)-1]
+	*rs = old[:len(old)-1]
+
+	return entry
+}
+
+// NewRetryQueue : Create an empty RetryQueue. IDs are retried after `backoff`, then 2x `backoff`,
+// 4x, and so on until they've failed `maxAttempts` times.
+func NewRetryQueue(maxAttempts int, backoff time.Duration) *RetryQueue {
+	return &RetryQueue{
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		attempts:    make(map[RiotID]int),
+		schedule:    make(retrySchedule, 0),
+	}
+}
+
+// Add : Queue an ID to be attempted immediately without counting it as a failure. Useful for
+// replaying ID's that previously exhausted their attempts.
+func (rq *RetryQueue) Add(id RiotID) {
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	rq.attempts[id] = 0
+	heap.Push(&rq.schedule, retryEntry{id: id, next: time.Now()})
+}
+
+// Requeue : Queue an ID to be attempted again immediately without counting a failure, i.e. when
+// its request was rate limited.
+func (rq *RetryQueue) Requeue(id RiotID) {
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	heap.Push(&rq.schedule, retryEntry{id: id, next: time.Now()})
+}
+
+// Fail : Record a failed attempt for the specified ID. Returns the number of failed attempts
+// and true if the ID will be retried, or false if it has run out of attempts and has been
+// removed from the queue.
+func (rq *RetryQueue) Fail(id RiotID) (int, bool) {
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	rq.attempts[id]++
+	failures := rq.attempts[id]
+
+	if failures >= rq.maxAttempts {
+		delete(rq.attempts, id)
+		return failures, false
+	}
+
+	wait := rq.backoff * time.Duration(1<<uint(failures-1))
+	heap.Push(&rq.schedule, retryEntry{id: id, next: time.Now().Add(wait)})
+
+	return failures, true
+}
+
+// Succeed : Forget about an ID once a request for it succeeds.
+func (rq *RetryQueue) Succeed(id RiotID) {
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	delete(rq.attempts, id)
+}
+
+// Next : Returns the next ID whose backoff has passed. The second return value is false if no
+// ID's are ready to be retried.
+func (rq *RetryQueue) Next() (RiotID, bool) {
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	if len(rq.schedule) > 0 && !rq.schedule[0].next.After(time.Now()) {
+		entry := heap.Pop(&rq.schedule).(retryEntry)
+
+		return entry.id, true
+	}
+
+	return -1, false
+}
+
+// Len : Returns the number of ID's waiting to be retried.
+func (rq *RetryQueue) Len() int {
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	return len(rq.schedule)
+}
+
+// Bytes : Encode every ID waiting to be retried along with its failed attempts and when it's
+// next due, so that retries survive a restart. See Load().
+func (rq *RetryQueue) Bytes() []byte {
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.BigEndian, uint64(len(rq.schedule)))
+
+	for _, entry := range rq.schedule {
+		binary.Write(buf, binary.BigEndian, []int64{
+			int64(entry.id),
+			int64(rq.attempts[entry.id]),
+			entry.next.UnixNano(),
+		})
+	}
+
+	return buf.Bytes()
+}
+
+// Load : Queue the ID's encoded by Bytes(), keeping their failed attempts and backoff.
+func (rq *RetryQueue) Load(buf []byte) error {
+	r := bytes.NewReader(buf)
+
+	var count uint64
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+
+	if count*24 != uint64(r.Len()) {
+		return errors.New("retry queue has the wrong length")
+	}
+
+	entries := make([]int64, 3*count)
+	if err := binary.Read(r, binary.BigEndian, entries); err != nil {
+		return err
+	}
+
+	rq.lock.Lock()
+	defer rq.lock.Unlock()
+
+	for i := 0; i < len(entries); i += 3 {
+		id := RiotID(entries[i])
+
+		rq.attempts[id] = int(entries[i+1])
+		heap.Push(&rq.schedule, retryEntry{id: id, next: time.Unix(0, entries[i+2])})
+	}
+
+	return nil
+}
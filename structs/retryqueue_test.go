@@ -0,0 +1,74 @@
+package structs
+
+import (
+	"testing"
+	"time"
+)
+
+// IDs shouldn't be available until their backoff has passed.
+func TestRetryBackoff(t *testing.T) {
+	rq := NewRetryQueue(3, 500*time.Millisecond)
+
+	rq.Fail(RiotID(10))
+
+	_, ready := rq.Next()
+	failIf(ready, t, "id available before backoff")
+
+	time.Sleep(600 * time.Millisecond)
+
+	id, ready := rq.Next()
+	failIf(!ready || id != 10, t, "id not available after backoff")
+}
+
+// IDs should be dropped once they run out of attempts.
+func TestRetryMaxAttempts(t *testing.T) {
+	rq := NewRetryQueue(3, time.Millisecond)
+
+	for i := 1; i <= 3; i++ {
+		attempts, retrying := rq.Fail(RiotID(10))
+
+		failIf(attempts != i, t, "incorrect attempt count")
+		failIf(retrying != (i < 3), t, "incorrect retry decision")
+	}
+
+	// A success resets the count.
+	rq.Fail(RiotID(20))
+	rq.Succeed(RiotID(20))
+	attempts, _ := rq.Fail(RiotID(20))
+	failIf(attempts != 1, t, "attempts not reset after success")
+}
+
+// Add() should make an ID available immediately.
+func TestRetryAdd(t *testing.T) {
+	rq := NewRetryQueue(3, time.Hour)
+	rq.Add(RiotID(5))
+
+	id, ready := rq.Next()
+	failIf(!ready || id != 5, t, "added id not available")
+	failIf(rq.Len() != 0, t, "queue not empty")
+}
+
+// Rate limited IDs are retried without using up an attempt, and saved queues keep their
+// attempts and backoff.
+func TestRetryRequeueAndSave(t *testing.T) {
+	rq := NewRetryQueue(2, time.Hour)
+
+	rq.Fail(RiotID(10))
+	rq.Requeue(RiotID(20))
+
+	restored := NewRetryQueue(2, time.Hour)
+	err := restored.Load(rq.Bytes())
+	failIf(err != nil, t, "couldn't load saved queue")
+	failIf(restored.Len() != 2, t, "saved queue has the wrong length")
+
+	id, ready := restored.Next()
+	failIf(!ready || id != 20, t, "requeued id not available immediately")
+
+	_, ready = restored.Next()
+	failIf(ready, t, "backoff not kept")
+
+	_, retrying := restored.Fail(RiotID(10))
+	failIf(retrying, t, "attempts not kept")
+
+	failIf(restored.Load([]byte{1, 2, 3}) == nil, t, "loaded a corrupt queue")
+}
@@ -0,0 +1,224 @@
+package structs
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// SchemaVersion : Version of the record format written by this version of matchgrab. Every
+// record is tagged with the version it was written with; records written before they were
+// tagged are version 1.
+//
+// Older records are upgraded as they're read (see MakeMatch()), and MatchStore.Migrate()
+// rewrites them in place so that doesn't need to happen every time.
+const SchemaVersion = 2
+
+// migration : Upgrades a decoded record from version `from` to the next version.
+type migration struct {
+	from        int
+	description string
+	upgrade     func(m *Match)
+}
+
+// migrations : Every migration, in order. Add a migration here whenever SchemaVersion is
+// increased.
+var migrations = []migration{
+	{
+		from:        1,
+		description: "set the platform of legacy records and key them by platform",
+		upgrade: func(m *Match) {
+			// Records written before multi-platform support were all retrieved from NA1.
+			if m.PlatformID == "" {
+				m.PlatformID = LegacyPlatform
+			}
+		},
+	},
+}
+
+// PendingMigrations : Describes the migrations that records written with schema `version` need,
+// in the order they're applied.
+func PendingMigrations(version int) []string {
+	pending := make([]string, 0)
+	for _, mig := range migrations {
+		if mig.from >= version {
+			pending = append(pending, mig.description)
+		}
+	}
+
+	return pending
+}
+
+// CorruptRecordError : A stored record couldn't be decoded.
+type CorruptRecordError struct {
+	Err error
+}
+
+func (e CorruptRecordError) Error() string {
+	return fmt.Sprintf("corrupt match record: %s", e.Err.Error())
+}
+
+// UnknownSchemaError : A stored record was written by a newer version of matchgrab and can't be
+// read by this one.
+type UnknownSchemaError struct {
+	Version int
+}
+
+func (e UnknownSchemaError) Error() string {
+	return fmt.Sprintf("match record has unknown schema version %d (newest supported is %d)", e.Version, SchemaVersion)
+}
+
+// upgrade : Apply every migration after `version` to a decoded match.
+func upgrade(m *Match, version int) {
+	for _, mig := range migrations {
+		if mig.from >= version {
+			mig.upgrade(m)
+		}
+	}
+}
+
+// MigrationStats : What MatchStore.Migrate() did.
+type MigrationStats struct {
+	Scanned    int // match records read
+	Migrated   int // records rewritten with the current schema
+	Rekeyed    int // legacy records moved to their platform key; included in Migrated
+	Duplicates int // legacy records removed because the match was also stored under its platform key
+	Failed     int // records that couldn't be decoded and were left alone
+}
+
+// Migrate : Upgrade every record written with an older schema and rewrite it in place, in
+// batches of `batchSize`. Each batch is written atomically. Once every record has been upgraded,
+// the store's schema version (see Stats()) is set to SchemaVersion.
+//
+// Records that can't be decoded are counted in MigrationStats.Failed and left in place, and the
+// store's schema version isn't changed.
+func (ms *MatchStore) Migrate(batchSize int) (MigrationStats, error) {
+	stats := MigrationStats{}
+
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	var start []byte
+	for {
+		next, err := ms.migrateBatch(start, batchSize, &stats)
+		if err != nil {
+			return stats, err
+		}
+
+		if next == nil {
+			break
+		}
+		start = next
+	}
+
+	// Records moved to their platform key are read again once the scan reaches them.
+	stats.Scanned -= stats.Rekeyed
+
+	if stats.Failed > 0 {
+		return stats, nil
+	}
+
+	ms.meta.lock.Lock()
+	defer ms.meta.lock.Unlock()
+
+	if ms.meta.stats.SchemaVersion == SchemaVersion {
+		return stats, nil
+	}
+
+	next := ms.meta.stats.copy()
+	next.SchemaVersion = SchemaVersion
+
+	batch := new(leveldb.Batch)
+	putStats(batch, ms.meta.stats, next)
+
+	if err := ms.db.Write(batch, nil); err != nil {
+		return stats, err
+	}
+
+	ms.meta.stats = next
+	return stats, nil
+}
+
+// migrateBatch : Upgrade up to `batchSize` records, starting at the key `start`. Returns the key
+// to start the next batch at, or nil once every key has been scanned.
+func (ms *MatchStore) migrateBatch(start []byte, batchSize int, stats *MigrationStats) ([]byte, error) {
+	// Hold the lock so matches added meanwhile aren't overwritten with older copies.
+	ms.meta.lock.Lock()
+	defer ms.meta.lock.Unlock()
+
+	iter := ms.db.NewIterator(&util.Range{Start: start}, nil)
+	defer iter.Release()
+
+	counts := ms.meta.stats.copy()
+	batch := new(leveldb.Batch)
+	changed := 0
+	var next []byte
+
+	for iter.Next() {
+		if changed >= batchSize {
+			next = append([]byte{}, iter.Key()...)
+			break
+		}
+
+		if !isMatchKey(iter.Key()) {
+			continue
+		}
+
+		stats.Scanned++
+
+		m, version, err := decodeMatch(iter.Value())
+		if err != nil {
+			stats.Failed++
+			continue
+		}
+
+		key := m.Key()
+		if bytes.Equal(key, iter.Key()) {
+			if version == SchemaVersion {
+				continue
+			}
+
+			// Counts and index entries don't change since the match is the same.
+			batch.Put(key, m.Bytes())
+			stats.Migrated++
+			changed++
+			continue
+		}
+
+		batch.Delete(append([]byte{}, iter.Key()...))
+		changed++
+
+		// Legacy records were indexed under LegacyPlatform already, so only the key changes.
+		// If the match was also stored under its platform key, that copy is newer.
+		if _, err := ms.db.Get(key, nil); err == nil {
+			counts.count(m, -1)
+			stats.Duplicates++
+			continue
+		} else if err != leveldb.ErrNotFound {
+			return nil, err
+		}
+
+		batch.Put(key, m.Bytes())
+		stats.Migrated++
+		stats.Rekeyed++
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	if changed > 0 {
+		putStats(batch, ms.meta.stats, counts)
+
+		if err := ms.db.Write(batch, nil); err != nil {
+			return nil, err
+		}
+
+		ms.meta.stats = counts
+	}
+
+	return next, nil
+}
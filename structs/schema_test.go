@@ -0,0 +1,101 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	protostruct "github.com/anyweez/matchgrab/proto"
+	"github.com/golang/protobuf/proto"
+)
+
+// legacyRecord : Encode a match the way it was stored before records were versioned or keyed
+// by platform.
+func legacyRecord(id RiotID, season int) []byte {
+	buf, _ := proto.Marshal(&protostruct.Match{GameID: int64(id), SeasonID: int32(season)})
+
+	return buf
+}
+
+// Make sure old records are upgraded when read, and unreadable ones are reported.
+func TestMakeMatchVersions(t *testing.T) {
+	m, err := MakeMatch(legacyRecord(1, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.GameID != 1 || m.PlatformID != LegacyPlatform {
+		t.Errorf("legacy record wasn't upgraded: %+v", m)
+	}
+
+	newer, _ := proto.Marshal(&protostruct.Match{GameID: 1, SchemaVersion: SchemaVersion + 1})
+	if _, err := MakeMatch(newer); err == nil {
+		t.Error("record with an unknown schema version was decoded")
+	} else if _, ok := err.(UnknownSchemaError); !ok {
+		t.Errorf("unexpected error for unknown schema: %s", err)
+	}
+
+	for _, buf := range [][]byte{nil, []byte("Not found"), {0x08}} {
+		if _, err := MakeMatch(buf); err == nil {
+			t.Errorf("corrupt record %q was decoded", buf)
+		} else if _, ok := err.(CorruptRecordError); !ok {
+			t.Errorf("unexpected error for corrupt record %q: %s", buf, err)
+		}
+	}
+}
+
+// Make sure Migrate() rewrites and rekeys legacy records without changing the counts.
+func TestMigrate(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	store := NewMatchStore(dir)
+	for i := 0; i < 5; i++ {
+		store.db.Put(RiotID(i).Bytes(), legacyRecord(RiotID(i), 8), nil)
+	}
+	store.db.Delete([]byte(metaCreated), nil)
+	store.db.Delete([]byte(metaCount), nil)
+	store.Close()
+
+	store = NewMatchStore(dir)
+	defer store.Close()
+
+	if version := store.Stats().SchemaVersion; version != 1 {
+		t.Fatalf("store with legacy records has schema version %d", version)
+	}
+
+	// Also stored under its platform key, i.e. recrawled after upgrading.
+	store.Add(Match{GameID: 4, PlatformID: LegacyPlatform, SeasonID: 9})
+	store.Flush()
+
+	stats, err := store.Migrate(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Scanned != 6 || stats.Migrated != 4 || stats.Rekeyed != 4 || stats.Duplicates != 1 || stats.Failed != 0 {
+		t.Errorf("unexpected migration stats: %+v", stats)
+	}
+
+	counts := store.Stats()
+	if counts.SchemaVersion != SchemaVersion || counts.Matches != 5 || counts.BySeason[8] != 4 || counts.BySeason[9] != 1 {
+		t.Errorf("unexpected stats after migrating: %+v", counts)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := store.db.Get(RiotID(i).Bytes(), nil); err == nil {
+			t.Errorf("legacy key for %d wasn't removed", i)
+		}
+
+		m, err := store.Get(LegacyPlatform, RiotID(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (i == 4) != (m.SeasonID == 9) {
+			t.Errorf("wrong copy of %d kept: %+v", i, m)
+		}
+	}
+
+	if stats, _ := store.Migrate(2); stats.Migrated != 0 || stats.Scanned != 5 {
+		t.Errorf("second migration changed records: %+v", stats)
+	}
+}
@@ -0,0 +1,306 @@
+package structs
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Snapshots are kept as numbered generations next to the store (i.e. matches.db-snapshot.12),
+// and <location>-snapshot is a symlink to the newest one. Readers always open a complete
+// generation: new generations are built under a temporary name and only linked once they're
+// finished. Readers open generations with OpenReadOnly() so their files never change.
+const (
+	generationSep = "."
+	tempSuffix    = ".tmp"
+
+	// Flush batches to the snapshot after this many changes.
+	snapshotBatchSize = 1000
+)
+
+// Snapshot : Copy a point-in-time view of the store into a new snapshot generation and make it
+// the current snapshot (<location>-snapshot). The new generation starts as a copy of an older
+// one, so only keys that were added, changed or removed since then are written.
+//
+// Readers may have the current generation open, so it's never copied from, and it isn't
+// removed until the snapshot after it's replaced. Otherwise generations beyond the newest
+// Config.SnapshotGenerations are removed. Returns the number of keys written.
+func (ms *MatchStore) Snapshot() (int, error) {
+	ms.dbLock.Lock()
+	defer ms.dbLock.Unlock()
+
+	link := ms.location + SnapshotSuffix
+
+	// Snapshots used to be copied straight to <location>-snapshot; keep the old copy as the
+	// first generation.
+	if info, err := os.Lstat(link); err == nil && info.IsDir() {
+		if err := os.Rename(link, generationPath(link, 0)); err != nil {
+			return 0, err
+		}
+	}
+
+	generations, err := snapshotGenerations(link)
+	if err != nil {
+		return 0, err
+	}
+
+	current := currentGeneration(link)
+
+	next := 1
+	if len(generations) > 0 {
+		next = generations[len(generations)-1] + 1
+	}
+
+	// Partial generations are left behind if the process stops while building one.
+	temp := generationPath(link, next) + tempSuffix
+	os.RemoveAll(temp)
+
+	// Start from the newest generation other than the current one, if there is one.
+	source := -1
+	for _, generation := range generations {
+		if generation != current {
+			source = generation
+		}
+	}
+
+	if source >= 0 {
+		err = copyGeneration(generationPath(link, source), temp)
+	} else {
+		err = os.MkdirAll(temp, 0755)
+	}
+	if err != nil {
+		os.RemoveAll(temp)
+		return 0, err
+	}
+
+	written, err := ms.copyTo(temp)
+	if err != nil {
+		os.RemoveAll(temp)
+		return 0, err
+	}
+
+	if err := os.Rename(temp, generationPath(link, next)); err != nil {
+		os.RemoveAll(temp)
+		return 0, err
+	}
+
+	// Renaming a new link over the old one swaps them atomically.
+	tempLink := link + tempSuffix
+	os.Remove(tempLink)
+
+	if err := os.Symlink(filepath.Base(generationPath(link, next)), tempLink); err != nil {
+		return written, err
+	}
+	if err := os.Rename(tempLink, link); err != nil {
+		return written, err
+	}
+
+	keep := ms.generations
+	if keep < 1 {
+		keep = 1
+	}
+
+	// Oldest first, not counting the new generation.
+	remove := len(generations) + 1 - keep
+	for _, generation := range generations {
+		if remove <= 0 {
+			break
+		}
+		if generation == current {
+			continue
+		}
+
+		os.RemoveAll(generationPath(link, generation))
+		remove--
+	}
+
+	return written, nil
+}
+
+// copyTo : Bring the database at `dir` up to date with a point-in-time view of the store.
+// Both are iterated in key order, so keys that already match are skipped without writing.
+func (ms *MatchStore) copyTo(dir string) (int, error) {
+	snapshot, err := ms.db.GetSnapshot()
+	if err != nil {
+		return 0, err
+	}
+	defer snapshot.Release()
+
+	dst, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+
+	src := snapshot.NewIterator(nil, nil)
+	defer src.Release()
+
+	// Iterators read from an implicit snapshot, so writes below don't affect this one.
+	existing := dst.NewIterator(nil, nil)
+	defer existing.Release()
+
+	written := 0
+	batch := new(leveldb.Batch)
+
+	srcOK, dstOK := src.Next(), existing.Next()
+	for srcOK || dstOK {
+		cmp := 0
+		switch {
+		case !dstOK:
+			cmp = -1
+		case !srcOK:
+			cmp = 1
+		default:
+			cmp = bytes.Compare(src.Key(), existing.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			batch.Put(src.Key(), src.Value())
+			srcOK = src.Next()
+		case cmp > 0:
+			batch.Delete(existing.Key())
+			dstOK = existing.Next()
+		default:
+			if !bytes.Equal(src.Value(), existing.Value()) {
+				batch.Put(src.Key(), src.Value())
+			}
+			srcOK, dstOK = src.Next(), existing.Next()
+		}
+
+		if batch.Len() >= snapshotBatchSize {
+			if err := dst.Write(batch, nil); err != nil {
+				return written, err
+			}
+
+			written += batch.Len()
+			batch.Reset()
+		}
+	}
+
+	if err := src.Error(); err != nil {
+		return written, err
+	}
+	if err := existing.Error(); err != nil {
+		return written, err
+	}
+
+	if err := dst.Write(batch, nil); err != nil {
+		return written, err
+	}
+
+	return written + batch.Len(), nil
+}
+
+func generationPath(link string, generation int) string {
+	return link + generationSep + strconv.Itoa(generation)
+}
+
+// snapshotGenerations : Returns the numbers of all complete snapshot generations, oldest first.
+func snapshotGenerations(link string) ([]int, error) {
+	files, err := ioutil.ReadDir(filepath.Dir(link))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Base(link) + generationSep
+	generations := make([]int, 0)
+
+	for _, file := range files {
+		if !file.IsDir() || !strings.HasPrefix(file.Name(), prefix) {
+			continue
+		}
+
+		// Skips temporary directories as well, since they don't parse.
+		if generation, err := strconv.Atoi(strings.TrimPrefix(file.Name(), prefix)); err == nil {
+			generations = append(generations, generation)
+		}
+	}
+
+	sort.Ints(generations)
+
+	return generations, nil
+}
+
+// currentGeneration : Returns the generation that `link` points to, or -1 if it doesn't point to
+// one.
+func currentGeneration(link string) int {
+	target, err := os.Readlink(link)
+	if err != nil {
+		return -1
+	}
+
+	prefix := filepath.Base(link) + generationSep
+	if !strings.HasPrefix(target, prefix) {
+		return -1
+	}
+
+	generation, err := strconv.Atoi(strings.TrimPrefix(target, prefix))
+	if err != nil {
+		return -1
+	}
+
+	return generation
+}
+
+// copyGeneration : Copy a LevelDB database that's closed or only open read-only. Table files are never modified once written,
+// so they're hard linked when possible; everything else is copied.
+func copyGeneration(from string, to string) error {
+	if err := os.MkdirAll(to, 0755); err != nil {
+		return err
+	}
+
+	files, err := ioutil.ReadDir(from)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || file.Name() == "LOCK" {
+			continue
+		}
+
+		src := filepath.Join(from, file.Name())
+		dst := filepath.Join(to, file.Name())
+
+		ext := filepath.Ext(file.Name())
+		if ext == ".ldb" || ext == ".sst" {
+			if err := os.Link(src, dst); err == nil {
+				continue
+			}
+		}
+
+		if err := copyFile(src, dst); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(from string, to string) error {
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
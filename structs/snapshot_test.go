@@ -0,0 +1,187 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func snapshotCount(t *testing.T, location string) int {
+	snapshot, err := OpenReadOnly(location + SnapshotSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snapshot.Close()
+
+	count := 0
+	snapshot.Each(func(m *Match) {
+		count++
+	})
+
+	return count
+}
+
+// Make sure snapshots only copy what changed and old generations are cleaned up.
+func TestSnapshot(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	location := filepath.Join(dir, "matches.db")
+
+	store := NewMatchStore(location)
+	defer store.Close()
+
+	store.generations = 2
+
+	for i := 0; i < 20; i++ {
+		store.Add(Match{GameID: RiotID(i), PlatformID: "NA1"})
+	}
+	store.AddDeadLetter(DeadLetter{Kind: DeadMatch, Platform: "NA1", ID: RiotID(100)})
+
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := store.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := snapshotCount(t, location); count != 20 {
+		t.Errorf("snapshot has %d matches", count)
+	}
+
+	// The first generation is current, so the second can't start from it.
+	if second, err := store.Snapshot(); err != nil || second != first {
+		t.Fatalf("wrote %d keys after %d: %v", second, first, err)
+	}
+
+	// Only the new match and the removed dead letter should be written.
+	store.Add(Match{GameID: RiotID(20), PlatformID: "NA1"})
+	store.RemoveDeadLetter(DeadLetter{Kind: DeadMatch, Platform: "NA1", ID: RiotID(100)})
+
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	third, err := store.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The new match also has an entry in the time index, and the store's metadata has four
+	// keys that change with each write (total, season and queue counts, and last write time).
+	if third != 7 {
+		t.Errorf("wrote %d keys after %d", third, first)
+	}
+
+	if count := snapshotCount(t, location); count != 21 {
+		t.Errorf("snapshot has %d matches", count)
+	}
+
+	generations, _ := snapshotGenerations(location + SnapshotSuffix)
+	if len(generations) != 2 || generations[1] != 3 {
+		t.Errorf("unexpected generations: %v", generations)
+	}
+
+	if target, _ := os.Readlink(location + SnapshotSuffix); target != "matches.db-snapshot.3" {
+		t.Errorf("snapshot links to %s", target)
+	}
+}
+
+// Make sure a copy made by older versions becomes the first generation.
+func TestSnapshotMigrate(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	location := filepath.Join(dir, "matches.db")
+
+	old := makeMs(location+SnapshotSuffix, false)
+	old.Add(Match{GameID: RiotID(1), PlatformID: "NA1"})
+	old.Close()
+
+	store := NewMatchStore(location)
+	defer store.Close()
+
+	store.generations = 1
+	store.Add(Match{GameID: RiotID(2), PlatformID: "NA1"})
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := snapshotCount(t, location); count != 1 {
+		t.Errorf("snapshot has %d matches", count)
+	}
+
+	generations, _ := snapshotGenerations(location + SnapshotSuffix)
+	if len(generations) != 1 || generations[0] != 1 {
+		t.Errorf("unexpected generations: %v", generations)
+	}
+}
+
+// Make sure the generation a reader has open is neither copied from nor removed.
+func TestSnapshotReader(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	location := filepath.Join(dir, "matches.db")
+
+	store := NewMatchStore(location)
+	defer store.Close()
+
+	store.generations = 1
+	store.Add(Match{GameID: RiotID(1), PlatformID: "NA1"})
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := OpenReadOnly(location + SnapshotSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	if err := reader.Flush(); err != ErrReadOnly {
+		t.Errorf("flushed a read-only store: %v", err)
+	}
+
+	store.Add(Match{GameID: RiotID(2), PlatformID: "NA1"})
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first generation was current until now, so it's kept until the next snapshot.
+	generations, _ := snapshotGenerations(location + SnapshotSuffix)
+	if len(generations) != 2 {
+		t.Errorf("unexpected generations: %v", generations)
+	}
+
+	if _, err := reader.Get("NA1", RiotID(1)); err != nil {
+		t.Errorf("couldn't read the open generation: %v", err)
+	}
+	if count := snapshotCount(t, location); count != 2 {
+		t.Errorf("snapshot has %d matches", count)
+	}
+
+	if _, err := store.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+
+	generations, _ = snapshotGenerations(location + SnapshotSuffix)
+	if len(generations) != 2 || generations[0] != 2 {
+		t.Errorf("unexpected generations: %v", generations)
+	}
+}
@@ -0,0 +1,97 @@
+package structs
+
+import (
+	"encoding/binary"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// spillQueue : A FIFO queue of ID's kept in its own LevelDB database. IDLists use it to hold
+// everything that doesn't fit in memory. Keys are big-endian sequence numbers so LevelDB's
+// ordering matches insertion order. Not safe for concurrent use; IDList serializes access.
+type spillQueue struct {
+	db   *leveldb.DB
+	head uint64 // sequence number of the oldest item
+	tail uint64 // sequence number for the next item added
+}
+
+func spillKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+
+	return key
+}
+
+// openSpillQueue : Open (or create) a spill queue. Items left over from a previous run are kept.
+func openSpillQueue(path string) (*spillQueue, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sq := &spillQueue{db: db}
+
+	iter := db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	if iter.First() {
+		sq.head = binary.BigEndian.Uint64(iter.Key())
+	}
+	if iter.Last() {
+		sq.tail = binary.BigEndian.Uint64(iter.Key()) + 1
+	}
+
+	return sq, iter.Error()
+}
+
+// len : Number of items in the queue.
+func (sq *spillQueue) len() uint64 {
+	return sq.tail - sq.head
+}
+
+// push : Add an item to the end of the queue.
+func (sq *spillQueue) push(id RiotID) error {
+	if err := sq.db.Put(spillKey(sq.tail), id.Bytes(), nil); err != nil {
+		return err
+	}
+
+	sq.tail++
+	return nil
+}
+
+// pop : Remove and return up to `n` items from the front of the queue.
+func (sq *spillQueue) pop(n int) ([]RiotID, error) {
+	if remaining := sq.len(); uint64(n) > remaining {
+		n = int(remaining)
+	}
+
+	ids := make([]RiotID, 0, n)
+	batch := new(leveldb.Batch)
+
+	iter := sq.db.NewIterator(&util.Range{
+		Start: spillKey(sq.head),
+		Limit: spillKey(sq.head + uint64(n)),
+	}, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		ids = append(ids, RiotID(binary.BigEndian.Uint64(iter.Value())))
+		batch.Delete(append([]byte{}, iter.Key()...))
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	if err := sq.db.Write(batch, nil); err != nil {
+		return nil, err
+	}
+
+	sq.head += uint64(n)
+	return ids, nil
+}
+
+func (sq *spillQueue) close() error {
+	return sq.db.Close()
+}
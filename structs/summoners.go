@@ -0,0 +1,75 @@
+package structs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const summonerPrefix = "summoner:"
+
+// SummonerRecord : When a summoner's matchlist was last requested. Crawlers use these to revisit
+// summoners after a while and only request matches they haven't seen yet.
+type SummonerRecord struct {
+	Platform    string    `json:"platform"`
+	ID          RiotID    `json:"id"`
+	LastCrawled time.Time `json:"last_crawled"`
+
+	// Creation time (in milliseconds, like MatchSummary.Timestamp) of the newest match in the
+	// summoner's matchlist; zero if they didn't have any.
+	LatestMatch int64 `json:"latest_match"`
+}
+
+func summonerKey(platform string, id RiotID) []byte {
+	return append([]byte(summonerPrefix+platform+":"), id.Bytes()...)
+}
+
+// SetSummoner : Record that a summoner was crawled. Replaces any previous record for the same
+// summoner.
+func (ms *MatchStore) SetSummoner(sr SummonerRecord) error {
+	raw, err := json.Marshal(sr)
+	if err != nil {
+		return err
+	}
+
+	return ms.db.Put(summonerKey(sr.Platform, sr.ID), raw, nil)
+}
+
+// Summoner : Look up the record for a summoner. The second return value is false if the summoner
+// hasn't been crawled on this platform yet.
+func (ms *MatchStore) Summoner(platform string, id RiotID) (SummonerRecord, bool, error) {
+	var sr SummonerRecord
+
+	raw, err := ms.db.Get(summonerKey(platform, id), nil)
+	if err == leveldb.ErrNotFound {
+		return sr, false, nil
+	} else if err != nil {
+		return sr, false, err
+	}
+
+	if err := json.Unmarshal(raw, &sr); err != nil {
+		return sr, false, err
+	}
+
+	return sr, true, nil
+}
+
+// Summoners : Iterate over the records for every summoner crawled on a platform.
+func (ms *MatchStore) Summoners(platform string, fn func(SummonerRecord)) error {
+	iter := ms.db.NewIterator(util.BytesPrefix([]byte(summonerPrefix+platform+":")), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		var sr SummonerRecord
+
+		if err := json.Unmarshal(iter.Value(), &sr); err != nil {
+			return err
+		}
+
+		fn(sr)
+	}
+
+	return iter.Error()
+}
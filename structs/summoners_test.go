@@ -0,0 +1,50 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// Make sure summoner records are kept per platform and don't show up as matches.
+func TestSummoners(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(dir + SnapshotSuffix)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	if _, found, err := store.Summoner("NA1", RiotID(1)); found || err != nil {
+		t.Fatal("found a summoner that was never crawled")
+	}
+
+	crawled := time.Date(2017, 8, 1, 0, 0, 0, 0, time.UTC)
+	store.SetSummoner(SummonerRecord{Platform: "NA1", ID: RiotID(1), LastCrawled: crawled, LatestMatch: 1000})
+	store.SetSummoner(SummonerRecord{Platform: "NA1", ID: RiotID(2), LastCrawled: crawled})
+	store.SetSummoner(SummonerRecord{Platform: "EUW1", ID: RiotID(1), LastCrawled: crawled})
+
+	sr, found, err := store.Summoner("NA1", RiotID(1))
+	if !found || err != nil || !sr.LastCrawled.Equal(crawled) || sr.LatestMatch != 1000 {
+		t.Errorf("unexpected record: %+v", sr)
+	}
+
+	count := 0
+	store.Summoners("NA1", func(sr SummonerRecord) {
+		if sr.Platform != "NA1" {
+			t.Error("returned a summoner from another platform")
+		}
+
+		count++
+	})
+
+	if count != 2 {
+		t.Errorf("found %d summoners", count)
+	}
+
+	store.Each(func(m *Match) {
+		t.Error("summoner returned as a match")
+	})
+}
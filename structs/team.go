@@ -0,0 +1,110 @@
+package structs
+
+import (
+	protostruct "github.com/anyweez/matchgrab/proto"
+)
+
+// Team IDs used by Riot for each side of the map.
+const (
+	BlueTeam = 100
+	RedTeam  = 200
+)
+
+// Team : Stores information about one side of a match, including which champions it banned and
+// how many objectives it took.
+type Team struct {
+	TeamID int  `json:"teamId"`
+	Winner bool `json:"winner"`
+	Bans   []Ban
+
+	FirstBlood      bool `json:"firstBlood"`
+	FirstTower      bool `json:"firstTower"`
+	FirstInhibitor  bool `json:"firstInhibitor"`
+	FirstBaron      bool `json:"firstBaron"`
+	FirstDragon     bool `json:"firstDragon"`
+	FirstRiftHerald bool `json:"firstRiftHerald"`
+
+	TowerKills      int `json:"towerKills"`
+	InhibitorKills  int `json:"inhibitorKills"`
+	BaronKills      int `json:"baronKills"`
+	DragonKills     int `json:"dragonKills"`
+	RiftHeraldKills int `json:"riftHeraldKills"`
+}
+
+// Ban : A champion banned by a team. PickTurn is the order the ban was made in, starting at 1.
+type Ban struct {
+	ChampionID RiotID `json:"championId"`
+	PickTurn   int    `json:"pickTurn"`
+}
+
+// Team : Returns the team with the specified ID, or nil if the match doesn't have it (i.e. it
+// was stored before team data was kept).
+func (m *Match) Team(teamID int) *Team {
+	for i := range m.Teams {
+		if m.Teams[i].TeamID == teamID {
+			return &m.Teams[i]
+		}
+	}
+
+	return nil
+}
+
+// toProto : Convert to the representation used by Match.Bytes().
+func (t Team) toProto() *protostruct.Team {
+	bans := make([]*protostruct.Ban, 0, len(t.Bans))
+	for _, b := range t.Bans {
+		bans = append(bans, &protostruct.Ban{
+			ChampionID: int64(b.ChampionID),
+			PickTurn:   int32(b.PickTurn),
+		})
+	}
+
+	return &protostruct.Team{
+		TeamID: int32(t.TeamID),
+		Winner: t.Winner,
+		Bans:   bans,
+
+		FirstBlood:      t.FirstBlood,
+		FirstTower:      t.FirstTower,
+		FirstInhibitor:  t.FirstInhibitor,
+		FirstBaron:      t.FirstBaron,
+		FirstDragon:     t.FirstDragon,
+		FirstRiftHerald: t.FirstRiftHerald,
+
+		TowerKills:      int32(t.TowerKills),
+		InhibitorKills:  int32(t.InhibitorKills),
+		BaronKills:      int32(t.BaronKills),
+		DragonKills:     int32(t.DragonKills),
+		RiftHeraldKills: int32(t.RiftHeraldKills),
+	}
+}
+
+// makeTeam : Convert a team decoded by MakeMatch(). This is the inverse of Team.toProto().
+func makeTeam(pt *protostruct.Team) Team {
+	bans := make([]Ban, 0, len(pt.GetBans()))
+	for _, b := range pt.GetBans() {
+		bans = append(bans, Ban{
+			ChampionID: RiotID(b.GetChampionID()),
+			PickTurn:   int(b.GetPickTurn()),
+		})
+	}
+
+	return Team{
+		TeamID: int(pt.GetTeamID()),
+		Winner: pt.GetWinner(),
+		Bans:   bans,
+
+		FirstBlood:      pt.GetFirstBlood(),
+		FirstTower:      pt.GetFirstTower(),
+		FirstInhibitor:  pt.GetFirstInhibitor(),
+		FirstBaron:      pt.GetFirstBaron(),
+		FirstDragon:     pt.GetFirstDragon(),
+		FirstRiftHerald: pt.GetFirstRiftHerald(),
+
+		TowerKills:      int(pt.GetTowerKills()),
+		InhibitorKills:  int(pt.GetInhibitorKills()),
+		BaronKills:      int(pt.GetBaronKills()),
+		DragonKills:     int(pt.GetDragonKills()),
+		RiftHeraldKills: int(pt.GetRiftHeraldKills()),
+	}
+}
@@ -0,0 +1,375 @@
+package structs
+
+import (
+	"sort"
+	"strconv"
+
+	protostruct "github.com/anyweez/matchgrab/proto"
+	"github.com/golang/protobuf/proto"
+)
+
+// Kinds of timeline events; see TimelineEvent.Type. Riot sends a few others as well (i.e.
+// ITEM_UNDO and ELITE_MONSTER_KILL) and those are kept as-is.
+const (
+	EventChampionKill  = "CHAMPION_KILL"
+	EventWardPlaced    = "WARD_PLACED"
+	EventWardKill      = "WARD_KILL"
+	EventBuildingKill  = "BUILDING_KILL"
+	EventItemPurchased = "ITEM_PURCHASED"
+	EventItemSold      = "ITEM_SOLD"
+	EventSkillLevelUp  = "SKILL_LEVEL_UP"
+)
+
+type rawPosition struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+}
+
+// APITimeline : Raw data returned from Riot's timeline endpoint. Converted to Timeline using
+// ToTimeline().
+type APITimeline struct {
+	FrameInterval int64 `json:"frameInterval"`
+
+	Frames []struct {
+		Timestamp int64 `json:"timestamp"`
+
+		// Keyed by participant ID ("1" through "10").
+		ParticipantFrames map[string]struct {
+			ParticipantID       int32       `json:"participantId"`
+			Position            rawPosition `json:"position"`
+			CurrentGold         int32       `json:"currentGold"`
+			TotalGold           int32       `json:"totalGold"`
+			Level               int32       `json:"level"`
+			XP                  int32       `json:"xp"`
+			MinionsKilled       int32       `json:"minionsKilled"`
+			JungleMinionsKilled int32       `json:"jungleMinionsKilled"`
+		} `json:"participantFrames"`
+
+		Events []struct {
+			Type          string      `json:"type"`
+			Timestamp     int64       `json:"timestamp"`
+			ParticipantID int32       `json:"participantId"`
+			Position      rawPosition `json:"position"`
+
+			KillerID                int32   `json:"killerId"`
+			VictimID                int32   `json:"victimId"`
+			AssistingParticipantIDs []int32 `json:"assistingParticipantIds"`
+
+			ItemID   int32 `json:"itemId"`
+			AfterID  int32 `json:"afterId"`
+			BeforeID int32 `json:"beforeId"`
+
+			SkillSlot   int32  `json:"skillSlot"`
+			LevelUpType string `json:"levelUpType"`
+
+			WardType  string `json:"wardType"`
+			CreatorID int32  `json:"creatorId"`
+
+			TeamID         int32  `json:"teamId"`
+			BuildingType   string `json:"buildingType"`
+			LaneType       string `json:"laneType"`
+			TowerType      string `json:"towerType"`
+			MonsterType    string `json:"monsterType"`
+			MonsterSubType string `json:"monsterSubType"`
+		} `json:"events"`
+	} `json:"frames"`
+}
+
+// Timeline : What happened over the course of a match, one frame per FrameInterval
+// milliseconds. Participant ID's match the order of Match.Participants, starting at 1.
+type Timeline struct {
+	GameID        RiotID
+	PlatformID    string
+	FrameInterval int64
+
+	Frames []Frame
+}
+
+// Frame : The state of each participant at a point in the match, along with the events that
+// happened since the previous frame.
+type Frame struct {
+	Timestamp int64 // milliseconds since the match started
+
+	ParticipantFrames []ParticipantFrame // ordered by participant ID
+	Events            []TimelineEvent
+}
+
+// ParticipantFrame : A participant's position and totals at the time of a frame.
+type ParticipantFrame struct {
+	ParticipantID int32
+	X             int32
+	Y             int32
+
+	CurrentGold         int32
+	TotalGold           int32
+	Level               int32
+	XP                  int32
+	MinionsKilled       int32
+	JungleMinionsKilled int32
+}
+
+// TimelineEvent : Something that happened during a match. Which fields are set depends on the
+// type of event, i.e. ItemID is only set for item events.
+type TimelineEvent struct {
+	Type          string
+	Timestamp     int64
+	ParticipantID int32
+	X             int32
+	Y             int32
+
+	KillerID                int32
+	VictimID                int32
+	AssistingParticipantIDs []int32
+
+	ItemID   int32
+	AfterID  int32
+	BeforeID int32
+
+	SkillSlot   int32
+	LevelUpType string
+
+	WardType  string
+	CreatorID int32
+
+	TeamID         int32
+	BuildingType   string
+	LaneType       string
+	TowerType      string
+	MonsterType    string
+	MonsterSubType string
+}
+
+// ToTimeline : Convert raw API data to a Timeline. The timeline endpoint doesn't say which match
+// it belongs to, so the caller provides it.
+func ToTimeline(platform string, gameID RiotID, raw APITimeline) Timeline {
+	timeline := Timeline{
+		GameID:        gameID,
+		PlatformID:    platform,
+		FrameInterval: raw.FrameInterval,
+		Frames:        make([]Frame, 0, len(raw.Frames)),
+	}
+
+	for _, rf := range raw.Frames {
+		frame := Frame{
+			Timestamp:         rf.Timestamp,
+			ParticipantFrames: make([]ParticipantFrame, 0, len(rf.ParticipantFrames)),
+			Events:            make([]TimelineEvent, 0, len(rf.Events)),
+		}
+
+		for key, pf := range rf.ParticipantFrames {
+			id := pf.ParticipantID
+			if id == 0 {
+				parsed, _ := strconv.Atoi(key)
+				id = int32(parsed)
+			}
+
+			frame.ParticipantFrames = append(frame.ParticipantFrames, ParticipantFrame{
+				ParticipantID:       id,
+				X:                   pf.Position.X,
+				Y:                   pf.Position.Y,
+				CurrentGold:         pf.CurrentGold,
+				TotalGold:           pf.TotalGold,
+				Level:               pf.Level,
+				XP:                  pf.XP,
+				MinionsKilled:       pf.MinionsKilled,
+				JungleMinionsKilled: pf.JungleMinionsKilled,
+			})
+		}
+
+		// Maps don't keep their order, so put participants back in order.
+		sort.Slice(frame.ParticipantFrames, func(i, j int) bool {
+			return frame.ParticipantFrames[i].ParticipantID < frame.ParticipantFrames[j].ParticipantID
+		})
+
+		for _, e := range rf.Events {
+			frame.Events = append(frame.Events, TimelineEvent{
+				Type:                    e.Type,
+				Timestamp:               e.Timestamp,
+				ParticipantID:           e.ParticipantID,
+				X:                       e.Position.X,
+				Y:                       e.Position.Y,
+				KillerID:                e.KillerID,
+				VictimID:                e.VictimID,
+				AssistingParticipantIDs: e.AssistingParticipantIDs,
+				ItemID:                  e.ItemID,
+				AfterID:                 e.AfterID,
+				BeforeID:                e.BeforeID,
+				SkillSlot:               e.SkillSlot,
+				LevelUpType:             e.LevelUpType,
+				WardType:                e.WardType,
+				CreatorID:               e.CreatorID,
+				TeamID:                  e.TeamID,
+				BuildingType:            e.BuildingType,
+				LaneType:                e.LaneType,
+				TowerType:               e.TowerType,
+				MonsterType:             e.MonsterType,
+				MonsterSubType:          e.MonsterSubType,
+			})
+		}
+
+		timeline.Frames = append(timeline.Frames, frame)
+	}
+
+	return timeline
+}
+
+// Key : Returns the key used to store this timeline. See TimelineKey().
+func (t Timeline) Key() []byte {
+	return TimelineKey(t.PlatformID, t.GameID)
+}
+
+// Bytes : Encode the timeline using the Timeline message in match.proto.
+func (t Timeline) Bytes() []byte {
+	frames := make([]*protostruct.Frame, 0, len(t.Frames))
+
+	for _, f := range t.Frames {
+		pfs := make([]*protostruct.ParticipantFrame, 0, len(f.ParticipantFrames))
+		for _, pf := range f.ParticipantFrames {
+			pfs = append(pfs, &protostruct.ParticipantFrame{
+				ParticipantID:       pf.ParticipantID,
+				X:                   pf.X,
+				Y:                   pf.Y,
+				CurrentGold:         pf.CurrentGold,
+				TotalGold:           pf.TotalGold,
+				Level:               pf.Level,
+				XP:                  pf.XP,
+				MinionsKilled:       pf.MinionsKilled,
+				JungleMinionsKilled: pf.JungleMinionsKilled,
+			})
+		}
+
+		events := make([]*protostruct.TimelineEvent, 0, len(f.Events))
+		for _, e := range f.Events {
+			events = append(events, &protostruct.TimelineEvent{
+				Type:                    e.Type,
+				Timestamp:               e.Timestamp,
+				ParticipantID:           e.ParticipantID,
+				X:                       e.X,
+				Y:                       e.Y,
+				KillerID:                e.KillerID,
+				VictimID:                e.VictimID,
+				AssistingParticipantIDs: e.AssistingParticipantIDs,
+				ItemID:                  e.ItemID,
+				AfterID:                 e.AfterID,
+				BeforeID:                e.BeforeID,
+				SkillSlot:               e.SkillSlot,
+				LevelUpType:             e.LevelUpType,
+				WardType:                e.WardType,
+				CreatorID:               e.CreatorID,
+				TeamID:                  e.TeamID,
+				BuildingType:            e.BuildingType,
+				LaneType:                e.LaneType,
+				TowerType:               e.TowerType,
+				MonsterType:             e.MonsterType,
+				MonsterSubType:          e.MonsterSubType,
+			})
+		}
+
+		frames = append(frames, &protostruct.Frame{
+			Timestamp:         f.Timestamp,
+			ParticipantFrames: pfs,
+			Events:            events,
+		})
+	}
+
+	buf, _ := proto.Marshal(&protostruct.Timeline{
+		GameID:        int64(t.GameID),
+		PlatformID:    t.PlatformID,
+		FrameInterval: t.FrameInterval,
+		Frames:        frames,
+	})
+
+	return buf
+}
+
+// MakeTimeline : Convert an encoded byte array back into a timeline. This is the inverse of
+// Timeline.Bytes().
+func MakeTimeline(buf []byte) (*Timeline, error) {
+	pt := protostruct.Timeline{}
+	if err := proto.Unmarshal(buf, &pt); err != nil {
+		return nil, err
+	}
+
+	t := &Timeline{
+		GameID:        RiotID(pt.GetGameID()),
+		PlatformID:    pt.GetPlatformID(),
+		FrameInterval: pt.GetFrameInterval(),
+		Frames:        make([]Frame, 0, len(pt.GetFrames())),
+	}
+
+	for _, f := range pt.GetFrames() {
+		frame := Frame{
+			Timestamp:         f.GetTimestamp(),
+			ParticipantFrames: make([]ParticipantFrame, 0, len(f.GetParticipantFrames())),
+			Events:            make([]TimelineEvent, 0, len(f.GetEvents())),
+		}
+
+		for _, pf := range f.GetParticipantFrames() {
+			frame.ParticipantFrames = append(frame.ParticipantFrames, ParticipantFrame{
+				ParticipantID:       pf.GetParticipantID(),
+				X:                   pf.GetX(),
+				Y:                   pf.GetY(),
+				CurrentGold:         pf.GetCurrentGold(),
+				TotalGold:           pf.GetTotalGold(),
+				Level:               pf.GetLevel(),
+				XP:                  pf.GetXP(),
+				MinionsKilled:       pf.GetMinionsKilled(),
+				JungleMinionsKilled: pf.GetJungleMinionsKilled(),
+			})
+		}
+
+		for _, e := range f.GetEvents() {
+			frame.Events = append(frame.Events, TimelineEvent{
+				Type:                    e.GetType(),
+				Timestamp:               e.GetTimestamp(),
+				ParticipantID:           e.GetParticipantID(),
+				X:                       e.GetX(),
+				Y:                       e.GetY(),
+				KillerID:                e.GetKillerID(),
+				VictimID:                e.GetVictimID(),
+				AssistingParticipantIDs: e.GetAssistingParticipantIDs(),
+				ItemID:                  e.GetItemID(),
+				AfterID:                 e.GetAfterID(),
+				BeforeID:                e.GetBeforeID(),
+				SkillSlot:               e.GetSkillSlot(),
+				LevelUpType:             e.GetLevelUpType(),
+				WardType:                e.GetWardType(),
+				CreatorID:               e.GetCreatorID(),
+				TeamID:                  e.GetTeamID(),
+				BuildingType:            e.GetBuildingType(),
+				LaneType:                e.GetLaneType(),
+				TowerType:               e.GetTowerType(),
+				MonsterType:             e.GetMonsterType(),
+				MonsterSubType:          e.GetMonsterSubType(),
+			})
+		}
+
+		t.Frames = append(t.Frames, frame)
+	}
+
+	return t, nil
+}
+
+const timelinePrefix = "timeline:"
+
+// TimelineKey : Returns the LevelDB key for a match's timeline. Timelines are kept next to the
+// matches they belong to but in their own keyspace, so they're never returned by Each().
+func TimelineKey(platform string, id RiotID) []byte {
+	return append([]byte(timelinePrefix+platform+":"), id.Bytes()...)
+}
+
+// AddTimeline : Store a timeline, replacing any previous copy for the same match.
+func (ms *MatchStore) AddTimeline(t Timeline) error {
+	return ms.db.Put(t.Key(), t.Bytes(), nil)
+}
+
+// GetTimeline : Retrieve the timeline for a match on the specified platform. Returns
+// leveldb.ErrNotFound if it hasn't been stored.
+func (ms *MatchStore) GetTimeline(platform string, id RiotID) (*Timeline, error) {
+	raw, err := ms.db.Get(TimelineKey(platform, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeTimeline(raw)
+}
@@ -0,0 +1,86 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func sampleTimeline() Timeline {
+	return Timeline{
+		GameID:        RiotID(2500000000),
+		PlatformID:    "NA1",
+		FrameInterval: 60000,
+		Frames: []Frame{
+			{
+				Timestamp: 0,
+				ParticipantFrames: []ParticipantFrame{
+					{ParticipantID: 1, X: 560, Y: 581, CurrentGold: 500, TotalGold: 500, Level: 1},
+					{ParticipantID: 2, X: 14340, Y: 14391, CurrentGold: 500, TotalGold: 500, Level: 1},
+				},
+				Events: []TimelineEvent{
+					{Type: EventItemPurchased, Timestamp: 1000, ParticipantID: 1, ItemID: 1055},
+				},
+			},
+			{
+				Timestamp: 60000,
+				ParticipantFrames: []ParticipantFrame{
+					{ParticipantID: 1, X: 5000, Y: 5200, CurrentGold: 120, TotalGold: 720, Level: 2, XP: 280, MinionsKilled: 3},
+					{ParticipantID: 2, X: 9000, Y: 9100, CurrentGold: 140, TotalGold: 740, Level: 2, XP: 300, MinionsKilled: 4},
+				},
+				Events: []TimelineEvent{
+					{Type: EventChampionKill, Timestamp: 95000, X: 7000, Y: 7100, KillerID: 1, VictimID: 2, AssistingParticipantIDs: []int32{3, 4}},
+					{Type: EventBuildingKill, Timestamp: 110000, KillerID: 1, TeamID: RedTeam, BuildingType: "TOWER_BUILDING", LaneType: "MID_LANE", TowerType: "OUTER_TURRET"},
+				},
+			},
+		},
+	}
+}
+
+// Make sure timelines survive a trip through the store and stay out of Each().
+func TestTimelineStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(dir + SnapshotSuffix)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	if _, err := store.GetTimeline("NA1", RiotID(2500000000)); err != leveldb.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	original := sampleTimeline()
+	if err := store.AddTimeline(original); err != nil {
+		t.Fatal(err)
+	}
+
+	tl, err := store.GetTimeline("NA1", original.GameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tl.GameID != original.GameID || tl.FrameInterval != 60000 || len(tl.Frames) != 2 {
+		t.Fatalf("unexpected timeline: %+v", tl)
+	}
+
+	kill := tl.Frames[1].Events[0]
+	if kill.Type != EventChampionKill || kill.KillerID != 1 || kill.VictimID != 2 || len(kill.AssistingParticipantIDs) != 2 {
+		t.Errorf("unexpected event: %+v", kill)
+	}
+
+	if pf := tl.Frames[1].ParticipantFrames[1]; pf.ParticipantID != 2 || pf.TotalGold != 740 || pf.MinionsKilled != 4 {
+		t.Errorf("unexpected participant frame: %+v", pf)
+	}
+
+	if _, err := store.GetTimeline("EUW1", original.GameID); err != leveldb.ErrNotFound {
+		t.Error("found a timeline on the wrong platform")
+	}
+
+	store.Each(func(m *Match) {
+		t.Error("timeline returned as a match")
+	})
+}
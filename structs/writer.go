@@ -0,0 +1,250 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	writeQueueSize = 1000                   // matches waiting to be written before Add() blocks
+	writeBatchSize = 100                    // most matches written in a single batch
+	writeDelay     = 200 * time.Millisecond // longest a match waits for its batch to fill
+
+	// Failed batches are retried up to writeAttempts times, waiting twice as long after each
+	// attempt. Batches that fail every attempt are dropped.
+	writeAttempts = 5
+	writeBackoff  = 100 * time.Millisecond
+)
+
+// ErrStoreClosed : Returned by Flush() once the store has been closed.
+var ErrStoreClosed = errors.New("match store is closed")
+
+// ErrReadOnly : Returned by Flush() if the store was opened with OpenReadOnly().
+var ErrReadOnly = errors.New("match store is read-only")
+
+// WriteError : A batch of matches that couldn't be written. Sent to Errors() after every failed
+// attempt; Dropped is set on the last one.
+type WriteError struct {
+	Matches int
+	Attempt int
+	Dropped bool
+	Err     error
+}
+
+func (e WriteError) Error() string {
+	if e.Dropped {
+		return fmt.Sprintf("dropped %d matches after %d attempts: %s", e.Matches, e.Attempt, e.Err.Error())
+	}
+
+	return fmt.Sprintf("writing %d matches failed (attempt %d): %s", e.Matches, e.Attempt, e.Err.Error())
+}
+
+// WriteMetrics : How the store's writes are keeping up. See MatchStore.Metrics().
+type WriteMetrics struct {
+	Queued   int // matches added but not written yet
+	Written  int // matches written since the store was opened
+	Batches  int // batches written
+	Failures int // failed attempts, including ones that succeeded when retried
+	Dropped  int // matches in batches that failed every attempt
+
+	// Time between Add() and the match being written, for the oldest match in the most recent
+	// batch and the slowest so far.
+	LastLatency time.Duration
+	MaxLatency  time.Duration
+}
+
+// queuedMatch : A match waiting to be written, along with when it was added.
+type queuedMatch struct {
+	match Match
+	added time.Time
+}
+
+// Errors : Returns a channel that receives write errors (as WriteError's). Errors are dropped
+// if the channel fills up, so reading it is optional. Closed when the store is closed.
+func (ms *MatchStore) Errors() <-chan error {
+	return ms.errs
+}
+
+// Metrics : Returns the current write metrics.
+func (ms *MatchStore) Metrics() WriteMetrics {
+	ms.metricsLock.Lock()
+	defer ms.metricsLock.Unlock()
+
+	metrics := ms.metrics
+	metrics.Queued += len(ms.queue)
+
+	return metrics
+}
+
+// Flush : Write all matches added so far without waiting for their batch to fill. Once Flush()
+// returns they've been synced to disk. Returns the error from the last attempt if they couldn't
+// be written.
+func (ms *MatchStore) Flush() error {
+	if ms.readOnly {
+		return ErrReadOnly
+	}
+
+	done := make(chan error, 1)
+
+	select {
+	case ms.flushes <- done:
+		return <-done
+	case <-ms.closed:
+		return ErrStoreClosed
+	}
+}
+
+// writeLoop : Write queued matches in batches until the queue is closed. Batches are written
+// once they're full or the oldest match has waited writeDelay, whichever is first. The database
+// is closed once everything has been written.
+func (ms *MatchStore) writeLoop() {
+	pending := make([]queuedMatch, 0, writeBatchSize)
+	ticker := time.NewTicker(writeDelay / 4)
+
+	defer func() {
+		ticker.Stop()
+
+		ms.dbLock.Lock()
+		ms.db.Close()
+		ms.dbLock.Unlock()
+
+		close(ms.errs)
+		close(ms.closed)
+	}()
+
+	queued := func(qm queuedMatch) {
+		pending = append(pending, qm)
+
+		ms.metricsLock.Lock()
+		ms.metrics.Queued++
+		ms.metricsLock.Unlock()
+	}
+
+	for {
+		select {
+		case qm, ok := <-ms.queue:
+			if !ok {
+				ms.commit(pending)
+				return
+			}
+
+			queued(qm)
+			if len(pending) >= writeBatchSize {
+				ms.commit(pending)
+				pending = pending[:0]
+			}
+		case <-ticker.C:
+			if len(pending) > 0 && time.Since(pending[0].added) >= writeDelay {
+				ms.commit(pending)
+				pending = pending[:0]
+			}
+		case done := <-ms.flushes:
+			var err error
+
+			// Everything added before Flush() was called is already in the queue.
+		drain:
+			for {
+				select {
+				case qm, ok := <-ms.queue:
+					if !ok {
+						break drain
+					}
+
+					queued(qm)
+					if len(pending) >= writeBatchSize {
+						if failed := ms.commit(pending); failed != nil {
+							err = failed
+						}
+						pending = pending[:0]
+					}
+				default:
+					break drain
+				}
+			}
+
+			if failed := ms.commit(pending); failed != nil {
+				err = failed
+			}
+
+			done <- err
+			pending = pending[:0]
+		}
+	}
+}
+
+// commit : Write a batch of matches along with their index entries and updated counts, retrying
+// if it fails.
+// Batches are synced to disk before returning; since they're written at most a few times per
+// second, that costs much less than it would for individual matches.
+func (ms *MatchStore) commit(pending []queuedMatch) error {
+	if len(pending) == 0 {
+		return nil
+	}
+
+	matches := make([]Match, 0, len(pending))
+	for _, qm := range pending {
+		matches = append(matches, qm.match)
+	}
+
+	var err error
+	backoff := writeBackoff
+
+	for attempt := 1; attempt <= writeAttempts; attempt++ {
+		err = ms.putMatches(matches, func(batch *leveldb.Batch) error {
+			return ms.db.Write(batch, &opt.WriteOptions{Sync: true})
+		})
+
+		if err == nil {
+			break
+		}
+
+		ms.metricsLock.Lock()
+		ms.metrics.Failures++
+		ms.metricsLock.Unlock()
+
+		ms.reportError(WriteError{
+			Matches: len(pending),
+			Attempt: attempt,
+			Dropped: attempt == writeAttempts,
+			Err:     err,
+		})
+
+		if attempt < writeAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	latency := time.Since(pending[0].added)
+
+	ms.metricsLock.Lock()
+	defer ms.metricsLock.Unlock()
+
+	ms.metrics.Queued -= len(pending)
+
+	if err != nil {
+		ms.metrics.Dropped += len(pending)
+		return err
+	}
+
+	ms.metrics.Written += len(pending)
+	ms.metrics.Batches++
+	ms.metrics.LastLatency = latency
+	if latency > ms.metrics.MaxLatency {
+		ms.metrics.MaxLatency = latency
+	}
+
+	return nil
+}
+
+// reportError : Send an error to Errors() without blocking.
+func (ms *MatchStore) reportError(err error) {
+	select {
+	case ms.errs <- err:
+	default:
+	}
+}
@@ -0,0 +1,82 @@
+package structs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// Make sure Flush() writes everything that's been added and matches are batched.
+func TestFlush(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	store := NewMatchStore(dir)
+	defer store.Close()
+
+	for i := 0; i < 250; i++ {
+		store.Add(Match{GameID: RiotID(i), PlatformID: "NA1"})
+	}
+
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 250; i++ {
+		if _, err := store.Get("NA1", RiotID(i)); err != nil {
+			t.Fatalf("match %d wasn't written: %v", i, err)
+		}
+	}
+
+	metrics := store.Metrics()
+	if metrics.Written != 250 || metrics.Queued != 0 || metrics.Batches < 3 || metrics.Batches > 10 {
+		t.Errorf("unexpected metrics: %+v", metrics)
+	}
+
+	if err := store.Flush(); err != nil {
+		t.Errorf("flushing nothing failed: %v", err)
+	}
+}
+
+// Make sure failed writes are reported and retried instead of panicking.
+func TestWriteErrors(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test")
+	defer os.RemoveAll(dir)
+
+	store := NewMatchStore(dir)
+
+	// Every write fails once the database is closed underneath the store.
+	store.db.Close()
+	store.Add(Match{GameID: RiotID(1), PlatformID: "NA1"})
+
+	if err := store.Flush(); err == nil {
+		t.Fatal("flush succeeded")
+	}
+
+	attempts := 0
+	for len(store.Errors()) > 0 {
+		we, ok := (<-store.Errors()).(WriteError)
+		if !ok || we.Matches != 1 {
+			t.Errorf("unexpected error: %v", we)
+		}
+
+		attempts++
+		if we.Dropped != (attempts == writeAttempts) {
+			t.Errorf("attempt %d dropped: %v", attempts, we.Dropped)
+		}
+	}
+
+	if attempts != writeAttempts {
+		t.Errorf("reported %d attempts", attempts)
+	}
+
+	if metrics := store.Metrics(); metrics.Dropped != 1 || metrics.Failures != writeAttempts || metrics.Written != 0 {
+		t.Errorf("unexpected metrics: %+v", metrics)
+	}
+
+	store.Close()
+
+	if err := store.Flush(); err != ErrStoreClosed {
+		t.Errorf("flush after close returned %v", err)
+	}
+}